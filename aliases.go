@@ -0,0 +1,56 @@
+package dotconfig
+
+// DirAliases is like [Dir] but tries several application names, such as the current name
+// of a renamed application followed by its former names, so that a configuration written
+// under an old name is still found.
+//
+// Each name is searched in the full search order of [Dir] before the next one is tried,
+// and the first existing directory is returned. If none exists, it returns the path
+// that [Dir] returns for the first name. If no name is given, it returns "" and false.
+//
+// Parameters:
+//   - names: The application names to search configurations for, in order of preference
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirAliases(names ...string) (dir string, exist bool) {
+	var fallback string
+	for i, app := range names {
+		dir, exist := Dir(app)
+		if exist {
+			return dir, true
+		}
+		if i == 0 {
+			fallback = dir
+		}
+	}
+	return fallback, false
+}
+
+// FileAliases is like [DirAliases] but for the configuration file name, as located by [File].
+//
+// If no existing file is found, it returns the path and status that [File] returns for the first name.
+// If no name is given, it returns "" and [NotExists].
+//
+// Parameters:
+//   - name: The name of the configuration file to find
+//   - apps: The application names to search configurations for, in order of preference
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileAliases(name string, apps ...string) (path string, status FileStatus) {
+	var fallback string
+	var fallbackStatus FileStatus
+	for i, app := range apps {
+		path, status := File(app, name)
+		if status == FileExists {
+			return path, status
+		}
+		if i == 0 {
+			fallback, fallbackStatus = path, status
+		}
+	}
+	return fallback, fallbackStatus
+}
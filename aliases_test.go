@@ -0,0 +1,133 @@
+package dotconfig
+
+import (
+	"testing"
+)
+
+func TestDirAliases(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("old name found", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.oldapp"
+		}
+
+		dir, exist := DirAliases("newapp", "oldapp")
+
+		if dir != "/mock/home/.oldapp" {
+			t.Errorf("Expected dir to be '/mock/home/.oldapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("new name preferred", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.newapp" || dir == "/mock/xdg/oldapp"
+		}
+
+		dir, _ := DirAliases("newapp", "oldapp")
+
+		if dir != "/mock/home/.newapp" {
+			t.Errorf("Expected dir to be '/mock/home/.newapp', got '%s'", dir)
+		}
+	})
+
+	t.Run("none exists", func(t *testing.T) {
+		dirExists = func(dir string) bool { return false }
+
+		dir, exist := DirAliases("newapp", "oldapp")
+
+		if dir != "/mock/xdg/newapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/newapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		if dir, exist := DirAliases(); dir != "" || exist {
+			t.Errorf("Expected '' and false, got '%s' and %v", dir, exist)
+		}
+	})
+}
+
+func TestFileAliases(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("old name found", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.oldapp.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileAliases("config.yaml", "newapp", "oldapp")
+
+		if path != "/mock/home/.oldapp.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.oldapp.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("none exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/newapp/config.yaml" {
+				return BaseExists
+			}
+			return NotExists
+		}
+
+		path, status := FileAliases("config.yaml", "newapp", "oldapp")
+
+		if path != "/mock/xdg/newapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/newapp/config.yaml', got '%s'", path)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+
+	t.Run("no names", func(t *testing.T) {
+		if path, status := FileAliases("config.yaml"); path != "" || status != NotExists {
+			t.Errorf("Expected '' and NotExists, got '%s' and %v", path, status)
+		}
+	})
+}
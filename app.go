@@ -0,0 +1,80 @@
+package dotconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrInvalidApp is returned when an application name cannot be used
+// to build configuration paths safely.
+var ErrInvalidApp = errors.New("dotconfig: invalid application name")
+
+// ErrInvalidName is returned when a file name cannot be used to locate a configuration file.
+var ErrInvalidName = errors.New("dotconfig: invalid file name")
+
+// ValidateApp reports whether app can be used as an application name.
+//
+// An application name may be namespaced with "/", as in "acme/tool".
+// It rejects empty names, names with an empty segment or a segment equal to "." or "..",
+// and names containing [os.PathSeparator] other than "/", since these would produce
+// nonsensical paths or paths outside the configuration directories.
+// The returned error wraps [ErrInvalidApp].
+func ValidateApp(app string) error {
+	if app == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidApp)
+	}
+	if os.PathSeparator != '/' && strings.ContainsRune(app, os.PathSeparator) {
+		return fmt.Errorf("%w: %q contains a path separator", ErrInvalidApp, app)
+	}
+	for _, segment := range strings.Split(app, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidApp, app)
+		}
+	}
+	return nil
+}
+
+// normalizeApp trims leading and trailing separators from app, so that an accidental
+// "myapp/" or "/myapp" is treated as "myapp" and never escapes the configuration
+// directories or turns a dot-prefixed fallback into more than one path segment.
+// Use [ValidateApp] to reject such names instead.
+func normalizeApp(app string) string {
+	return strings.TrimFunc(app, func(r rune) bool {
+		return r == '/' || r == os.PathSeparator
+	})
+}
+
+// dotName returns the dot-prefixed name used for app in the home and current directories.
+// A namespaced name is flattened into a single path segment by replacing "/" with "-",
+// so that "acme/tool" becomes ".acme-tool".
+func dotName(app string) string {
+	return "." + strings.ReplaceAll(normalizeApp(app), "/", "-")
+}
+
+// DirE is like [Dir] but validates the application name with [ValidateApp] first.
+// It returns an error instead of building a path from an unsafe name.
+func DirE(app string) (dir string, exist bool, err error) {
+	if err := ValidateApp(app); err != nil {
+		return "", false, err
+	}
+	dir, exist = Dir(app)
+	return dir, exist, nil
+}
+
+// FileE is like [File] but validates the application name with [ValidateApp] first.
+// It returns an error instead of building a path from an unsafe name.
+//
+// It also rejects an empty file name with an error wrapping [ErrInvalidName],
+// since [File] treats it like the "." sentinel, which is most likely a mistake of the caller.
+func FileE(app, name string) (path string, status FileStatus, err error) {
+	if err := ValidateApp(app); err != nil {
+		return "", NotExists, err
+	}
+	if name == "" {
+		return "", NotExists, fmt.Errorf("%w: empty name", ErrInvalidName)
+	}
+	path, status = File(app, name)
+	return path, status, nil
+}
@@ -0,0 +1,251 @@
+package dotconfig
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidateApp(t *testing.T) {
+	testCases := []struct {
+		App   string
+		Valid bool
+	}{
+		{"myapp", true},
+		{"my.app", true},
+		{".myapp", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"acme/tool", true},
+		{"acme/tool/v2", true},
+		{"/myapp", false},
+		{"myapp/", false},
+		{"acme//tool", false},
+		{"../myapp", false},
+		{"acme/..", false},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateApp(tc.App)
+		if tc.Valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", tc.App, err)
+		}
+		if !tc.Valid && !errors.Is(err, ErrInvalidApp) {
+			t.Errorf("Expected %q to be invalid, got %v", tc.App, err)
+		}
+	}
+}
+
+func TestDirE(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/xdg/myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("valid app", func(t *testing.T) {
+		dir, exist, err := DirE("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("invalid app", func(t *testing.T) {
+		dir, exist, err := DirE("..")
+
+		if !errors.Is(err, ErrInvalidApp) {
+			t.Errorf("Expected ErrInvalidApp, got %v", err)
+		}
+		if dir != "" {
+			t.Errorf("Expected dir to be empty, got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestFileE(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	checkFile = func(path string) FileStatus {
+		if path == "/mock/xdg/myapp/config.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("valid app", func(t *testing.T) {
+		path, status, err := FileE("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		path, status, err := FileE("myapp", "")
+
+		if !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Expected ErrInvalidName, got %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected path to be empty, got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("dot name", func(t *testing.T) {
+		path, _, err := FileE("myapp", ".")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "/mock/xdg/myapp/myapp" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/myapp', got '%s'", path)
+		}
+	})
+
+	t.Run("invalid app", func(t *testing.T) {
+		path, status, err := FileE("", "config.yaml")
+
+		if !errors.Is(err, ErrInvalidApp) {
+			t.Errorf("Expected ErrInvalidApp, got %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected path to be empty, got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestInvalidAppDoesNotEscape(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	// Every directory exists, so that an escaping candidate would be found.
+	dirExists = func(dir string) bool { return true }
+	checkFile = func(path string) FileStatus { return FileExists }
+
+	for _, app := range []string{"..", ".", "", "myapp/..", "../..", "a/../../b"} {
+		if dir, exist := Dir(app); dir != "" || exist {
+			t.Errorf("Dir(%q): expected \"\" and false, got '%s' and %v", app, dir, exist)
+		}
+		if path, status := File(app, "x"); path != "" || status != NotExists {
+			t.Errorf("File(%q): expected \"\" and NotExists, got '%s' and %v", app, path, status)
+		}
+		if dirs := slices.Collect(ListDirs(app)); len(dirs) != 0 {
+			t.Errorf("ListDirs(%q): expected no candidates, got %v", app, dirs)
+		}
+		if files := slices.Collect(ListFiles(app, "x")); len(files) != 0 {
+			t.Errorf("ListFiles(%q): expected no candidates, got %v", app, files)
+		}
+	}
+
+	if dir, _ := DirWith("myapp", WithNamespace("..")); dir != "" {
+		t.Errorf("Expected dir with namespace '..' to be empty, got '%s'", dir)
+	}
+
+	// Leading and trailing separators are still trimmed rather than rejected.
+	if dir, _ := Dir("myapp/"); dir != "/mock/home/.config/myapp" {
+		t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+	}
+}
+
+func TestDotName(t *testing.T) {
+	testCases := []struct {
+		App      string
+		Expected string
+	}{
+		{"myapp", ".myapp"},
+		{"acme/tool", ".acme-tool"},
+		{"acme/tool/v2", ".acme-tool-v2"},
+	}
+
+	for _, tc := range testCases {
+		if got := dotName(tc.App); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestNormalizeApp(t *testing.T) {
+	testCases := []struct {
+		App      string
+		Expected string
+	}{
+		{"myapp", "myapp"},
+		{"myapp/", "myapp"},
+		{"myapp//", "myapp"},
+		{"/myapp", "myapp"},
+		{"/acme/tool/", "acme/tool"},
+	}
+
+	for _, tc := range testCases {
+		if got := normalizeApp(tc.App); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ListApps returns the names of the applications that have a configuration directory
+// under the standard locations, sorted lexically and without duplicates.
+//
+// It scans $XDG_CONFIG_HOME, or $HOME/.config when XDG_CONFIG_HOME is not set,
+// for directories, and $HOME for dot-prefixed directories, whose leading dot is stripped.
+// The $HOME/.config directory itself is not reported as an application.
+// Locations that cannot be read are skipped.
+//
+// Since any dot-prefixed directory in $HOME is reported, the result may include
+// directories that are not configurations, such as ".cache".
+//
+// Returns:
+//   - The discovered application names
+func ListApps() []string {
+	var apps []string
+	add := func(dir string, dotted bool) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if dotted {
+				if !strings.HasPrefix(name, ".") || name == ".config" {
+					continue
+				}
+			}
+			if !dirExists(filepath.Join(dir, name)) {
+				continue
+			}
+			if dotted {
+				name = name[1:]
+			}
+			apps = append(apps, name)
+		}
+	}
+	home, homeErr := homeDir()
+	if xdg := xdgConfigHome(); xdg != "" {
+		add(xdg, false)
+	} else if homeErr == nil {
+		add(filepath.Join(home, ".config"), false)
+	}
+	if homeErr == nil {
+		add(home, true)
+	}
+	slices.Sort(apps)
+	return slices.Compact(apps)
+}
@@ -0,0 +1,75 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestListApps(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	home := filepath.Join(tmp, "home")
+	xdg := filepath.Join(tmp, "xdg")
+	for _, dir := range []string{
+		filepath.Join(home, ".config", "alpha"),
+		filepath.Join(home, ".config", "beta"),
+		filepath.Join(home, ".beta"),
+		filepath.Join(home, ".gamma"),
+		filepath.Join(home, "visible"),
+		filepath.Join(xdg, "delta"),
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(home, ".profile"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	t.Run("without XDG", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+
+		apps := ListApps()
+
+		expected := []string{"alpha", "beta", "gamma"}
+		if !slices.Equal(apps, expected) {
+			t.Errorf("Expected %v, got %v", expected, apps)
+		}
+	})
+
+	t.Run("with XDG", func(t *testing.T) {
+		xdgConfigHome = func() string { return xdg }
+
+		apps := ListApps()
+
+		expected := []string{"beta", "delta", "gamma"}
+		if !slices.Equal(apps, expected) {
+			t.Errorf("Expected %v, got %v", expected, apps)
+		}
+	})
+
+	t.Run("home unavailable", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		if apps := ListApps(); len(apps) != 0 {
+			t.Errorf("Expected no apps, got %v", apps)
+		}
+	})
+}
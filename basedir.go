@@ -0,0 +1,125 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DataDir searches for a data directory for the specified application.
+// It follows the same conventions as [Dir] but uses the XDG data directory.
+//
+// The function tries the following locations in order:
+//
+//  1. $XDG_DATA_HOME/<app> (if XDG_DATA_HOME is set)
+//  2. $HOME/.local/share/<app> (if XDG_DATA_HOME is not set)
+//  3. $HOME/lib/<app> (on Plan9 only, see [WithPlan9Compat])
+//  4. $HOME/.<app> (if [os.UserHomeDir] returns no error)
+//  5. .<app> (in current directory, as last resort)
+//
+// The fallback semantics are the same as [Dir].
+//
+// Parameters:
+//   - app: The application name to search data directories for
+//
+// Returns:
+//   - dir: The data directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DataDir(app string) (dir string, exist bool) {
+	dir, status := searchDir(listIn(app, xdgDataHome, filepath.Join(".local", "share")), localDir(app))
+	return dir, status == FileExists
+}
+
+// CacheDir searches for a cache directory for the specified application.
+// It follows the same conventions as [Dir] but uses the XDG cache directory.
+//
+// The function tries the following locations in order:
+//
+//  1. $XDG_CACHE_HOME/<app> (if XDG_CACHE_HOME is set)
+//  2. $HOME/.cache/<app> (if XDG_CACHE_HOME is not set)
+//  3. $HOME/lib/<app> (on Plan9 only, see [WithPlan9Compat])
+//  4. $HOME/.<app> (if [os.UserHomeDir] returns no error)
+//  5. .<app> (in current directory, as last resort)
+//
+// The fallback semantics are the same as [Dir].
+//
+// Parameters:
+//   - app: The application name to search cache directories for
+//
+// Returns:
+//   - dir: The cache directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func CacheDir(app string) (dir string, exist bool) {
+	dir, status := searchDir(listIn(app, xdgCacheHome, ".cache"), localDir(app))
+	return dir, status == FileExists
+}
+
+// StateDir searches for a state directory for the specified application.
+// It follows the same conventions as [Dir] but uses the XDG state directory,
+// which is intended for logs, history, and other data that should persist
+// between restarts but is not important enough for the data directory.
+//
+// The function tries the following locations in order:
+//
+//  1. $XDG_STATE_HOME/<app> (if XDG_STATE_HOME is set)
+//  2. $HOME/.local/state/<app> (if XDG_STATE_HOME is not set)
+//  3. $HOME/lib/<app> (on Plan9 only, see [WithPlan9Compat])
+//  4. $HOME/.<app> (if [os.UserHomeDir] returns no error)
+//  5. .<app> (in current directory, as last resort)
+//
+// The fallback semantics are the same as [Dir].
+//
+// Parameters:
+//   - app: The application name to search state directories for
+//
+// Returns:
+//   - dir: The state directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func StateDir(app string) (dir string, exist bool) {
+	dir, status := searchDir(listIn(app, xdgStateHome, filepath.Join(".local", "state")), localDir(app))
+	return dir, status == FileExists
+}
+
+// RuntimeDir returns a runtime directory for the specified application,
+// intended for non-persistent files such as unix sockets and pid files.
+//
+// If XDG_RUNTIME_DIR is set and the directory exists, it returns
+// $XDG_RUNTIME_DIR/<app> and true. Otherwise it returns <tmp>/<app> and false,
+// where <tmp> is [os.TempDir].
+// Unlike the other functions, it never falls back to the home directory,
+// since runtime files must not outlive the user's session.
+// If app is not valid according to [ValidateApp], such as "../../etc", it returns "" and false.
+//
+// Parameters:
+//   - app: The application name to get the runtime directory for
+//
+// Returns:
+//   - dir: The runtime directory path
+//   - ok: Boolean indicating whether XDG_RUNTIME_DIR was used
+func RuntimeDir(app string) (dir string, ok bool) {
+	app = normalizeApp(app)
+	if ValidateApp(app) != nil {
+		return "", false
+	}
+	if runtime := xdgRuntimeDir(); runtime != "" && dirExists(runtime) {
+		return filepath.Join(runtime, app), true
+	}
+	return filepath.Join(tempDir(), app), false
+}
+
+var xdgDataHome = func() string {
+	return xdgEnv("XDG_DATA_HOME")
+}
+
+var xdgCacheHome = func() string {
+	return xdgEnv("XDG_CACHE_HOME")
+}
+
+var xdgStateHome = func() string {
+	return xdgEnv("XDG_STATE_HOME")
+}
+
+var xdgRuntimeDir = func() string {
+	return xdgEnv("XDG_RUNTIME_DIR")
+}
+
+var tempDir = os.TempDir
@@ -0,0 +1,371 @@
+package dotconfig
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDataDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgDataHome := xdgDataHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgDataHome = origXdgDataHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("XDG data exists", func(t *testing.T) {
+		// Mock functions
+		xdgDataHome = func() string { return "/mock/data" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/data/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DataDir("myapp")
+
+		if dir != "/mock/data/myapp" {
+			t.Errorf("Expected dir to be '/mock/data/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No XDG, home .local/share exists", func(t *testing.T) {
+		// Mock functions
+		xdgDataHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.local/share/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DataDir("myapp")
+
+		if dir != "/mock/home/.local/share/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.local/share/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No XDG, fallback to home dot dir", func(t *testing.T) {
+		// Mock functions
+		xdgDataHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DataDir("myapp")
+
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No directories exist, fallback to first option", func(t *testing.T) {
+		// Mock functions
+		xdgDataHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DataDir("myapp")
+
+		if dir != "/mock/home/.local/share/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.local/share/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("No locations available, use local dir", func(t *testing.T) {
+		// Mock functions
+		xdgDataHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		dir, exist := DataDir("myapp")
+
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestXdgDataHome(t *testing.T) {
+	expected := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", expected)
+	got := xdgDataHome()
+	if got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestCacheDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgCacheHome := xdgCacheHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgCacheHome = origXdgCacheHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("XDG cache exists", func(t *testing.T) {
+		// Mock functions
+		xdgCacheHome = func() string { return "/mock/cache" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/cache/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := CacheDir("myapp")
+
+		if dir != "/mock/cache/myapp" {
+			t.Errorf("Expected dir to be '/mock/cache/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No XDG, home .cache exists", func(t *testing.T) {
+		// Mock functions
+		xdgCacheHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.cache/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := CacheDir("myapp")
+
+		if dir != "/mock/home/.cache/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.cache/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No directories exist, fallback to first option", func(t *testing.T) {
+		// Mock functions
+		xdgCacheHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := CacheDir("myapp")
+
+		if dir != "/mock/home/.cache/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.cache/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestXdgCacheHome(t *testing.T) {
+	expected := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", expected)
+	got := xdgCacheHome()
+	if got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestStateDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgStateHome := xdgStateHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgStateHome = origXdgStateHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("XDG state exists", func(t *testing.T) {
+		// Mock functions
+		xdgStateHome = func() string { return "/mock/state" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/state/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := StateDir("myapp")
+
+		if dir != "/mock/state/myapp" {
+			t.Errorf("Expected dir to be '/mock/state/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No XDG, home .local/state exists", func(t *testing.T) {
+		// Mock functions
+		xdgStateHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.local/state/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := StateDir("myapp")
+
+		if dir != "/mock/home/.local/state/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.local/state/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No directories exist, fallback to first option", func(t *testing.T) {
+		// Mock functions
+		xdgStateHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := StateDir("myapp")
+
+		if dir != "/mock/home/.local/state/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.local/state/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestXdgStateHome(t *testing.T) {
+	expected := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", expected)
+	got := xdgStateHome()
+	if got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestRuntimeDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgRuntimeDir := xdgRuntimeDir
+	origDirExists := dirExists
+	origTempDir := tempDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgRuntimeDir = origXdgRuntimeDir
+		dirExists = origDirExists
+		tempDir = origTempDir
+	}()
+
+	tempDir = func() string { return "/mock/tmp" }
+
+	t.Run("XDG runtime dir exists", func(t *testing.T) {
+		// Mock functions
+		xdgRuntimeDir = func() string { return "/mock/run" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/run"
+		}
+
+		dir, ok := RuntimeDir("myapp")
+
+		if dir != "/mock/run/myapp" {
+			t.Errorf("Expected dir to be '/mock/run/myapp', got '%s'", dir)
+		}
+		if !ok {
+			t.Error("Expected ok to be true")
+		}
+	})
+
+	t.Run("XDG runtime dir not exist", func(t *testing.T) {
+		// Mock functions
+		xdgRuntimeDir = func() string { return "/mock/run" }
+		dirExists = func(dir string) bool { return false }
+
+		dir, ok := RuntimeDir("myapp")
+
+		if dir != "/mock/tmp/myapp" {
+			t.Errorf("Expected dir to be '/mock/tmp/myapp', got '%s'", dir)
+		}
+		if ok {
+			t.Error("Expected ok to be false")
+		}
+	})
+
+	t.Run("XDG runtime dir not set", func(t *testing.T) {
+		// Mock functions
+		xdgRuntimeDir = func() string { return "" }
+		dirExists = func(dir string) bool { return true }
+
+		dir, ok := RuntimeDir("myapp")
+
+		if dir != "/mock/tmp/myapp" {
+			t.Errorf("Expected dir to be '/mock/tmp/myapp', got '%s'", dir)
+		}
+		if ok {
+			t.Error("Expected ok to be false")
+		}
+	})
+
+	t.Run("invalid app", func(t *testing.T) {
+		// Mock functions
+		xdgRuntimeDir = func() string { return "/mock/run" }
+		dirExists = func(dir string) bool { return true }
+
+		for _, app := range []string{"../../etc", "..", ""} {
+			if dir, ok := RuntimeDir(app); dir != "" || ok {
+				t.Errorf("RuntimeDir(%q): expected \"\" and false, got '%s' and %v", app, dir, ok)
+			}
+		}
+	})
+}
+
+func TestXdgRuntimeDir(t *testing.T) {
+	expected := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", expected)
+	got := xdgRuntimeDir()
+	if got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
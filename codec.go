@@ -0,0 +1,77 @@
+package dotconfig
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec converts a configuration value to and from the bytes of a configuration file.
+// It is used by [Load] and [Save], so that any serialization format can be plugged in
+// without this package depending on it.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is a [Codec] using [encoding/json].
+// Marshal indents with two spaces and ends the output with a newline, for readable files.
+type JSONCodec struct{}
+
+// Marshal returns the indented JSON encoding of v.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Unmarshal parses the JSON-encoded data and stores the result in the value pointed to by v.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// Load reads the configuration file for the specified application using [ReadFile]
+// and decodes it into v with codec.
+//
+// If the file does not exist, it returns an error wrapping [fs.ErrNotExist] and leaves v unchanged.
+// A decoding error is annotated with the file path.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to read
+//   - v: The value to decode into, usually a pointer
+//   - codec: The codec that decodes the contents of the file
+//
+// Returns:
+//   - err: An error if the file could not be read or decoded
+func Load(app, name string, v any, codec Codec) error {
+	data, path, err := ReadFile(app, name)
+	if err != nil {
+		return err
+	}
+	if err := codec.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("dotconfig: decode %s: %w", path, err)
+	}
+	return nil
+}
+
+// Save encodes v with codec and writes it to the configuration file for the specified
+// application using [WriteFileAtomic], with the permission [DefaultFilePerm].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to write
+//   - v: The value to encode
+//   - codec: The codec that encodes the contents of the file
+//
+// Returns:
+//   - err: An error if v could not be encoded or the file could not be written
+func Save(app, name string, v any, codec Codec) error {
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("dotconfig: encode %s: %w", name, err)
+	}
+	_, err = WriteFileAtomic(app, name, data, DefaultFilePerm)
+	return err
+}
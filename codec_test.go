@@ -0,0 +1,107 @@
+package dotconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONCodec(t *testing.T) {
+	var codec JSONCodec
+
+	data, err := codec.Marshal(map[string]int{"port": 8080})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(data) != "{\n  \"port\": 8080\n}\n" {
+		t.Errorf("Expected indented JSON, got '%s'", data)
+	}
+
+	var v map[string]int
+	if err := codec.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if v["port"] != 8080 {
+		t.Errorf("Expected port to be 8080, got %d", v["port"])
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := filepath.Join(t.TempDir(), "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	type config struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+
+	t.Run("load not exists", func(t *testing.T) {
+		v := config{Name: "default"}
+		err := Load("myapp", "config.json", &v, JSONCodec{})
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected fs.ErrNotExist, got %v", err)
+		}
+		if v.Name != "default" {
+			t.Errorf("Expected value to be unchanged, got %+v", v)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		if err := Save("myapp", "config.json", config{Name: "server", Port: 8080}, JSONCodec{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkPerm(t, filepath.Join(xdg, "myapp", "config.json"), 0600)
+
+		var v config
+		if err := Load("myapp", "config.json", &v, JSONCodec{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if v.Name != "server" || v.Port != 8080 {
+			t.Errorf("Expected {server 8080}, got %+v", v)
+		}
+	})
+
+	t.Run("load malformed", func(t *testing.T) {
+		path := filepath.Join(xdg, "myapp", "broken.json")
+		if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		var v config
+		err := Load("myapp", "broken.json", &v, JSONCodec{})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected a decoding error, got %v", err)
+		}
+	})
+
+	t.Run("save unencodable", func(t *testing.T) {
+		err := Save("myapp", "bad.json", func() {}, JSONCodec{})
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		if _, err := os.Stat(filepath.Join(xdg, "myapp", "bad.json")); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected no file to be written, got %v", err)
+		}
+	})
+}
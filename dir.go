@@ -1,9 +1,15 @@
 package dotconfig
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"iter"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // Dir searches for a configuration directory for the specified application.
@@ -14,14 +20,20 @@ import (
 //
 //  1. $XDG_CONFIG_HOME/<app> (if XDG_CONFIG_HOME is set)
 //  2. $HOME/.config/<app> (if XDG_CONFIG_HOME is not set)
-//  3. $HOME/lib/<app> (for Plan9 compatibility)
+//  3. $HOME/lib/<app> (on Plan9 only, see [WithPlan9Compat])
 //  4. $HOME/.<app> (if [os.UserHomeDir] returns no error)
 //  5. .<app> (in current directory, as last resort)
 //
+// When running inside a Snap, that is, when SNAP and SNAP_USER_COMMON are set,
+// $SNAP_USER_COMMON/<app> is searched before all of these.
+// A relative XDG_CONFIG_HOME is ignored as if it were not set, as the XDG Base Directory Specification requires,
+// but a leading "~" that the shell did not expand is replaced with the home directory.
+//
 // If an existing directory is found, it returns the directory path and true.
 // If no existing directory is found but potential locations were checked,
 // it returns the first potential location and false.
 // If no locations could be determined, it returns ".<app>" and whether it exists.
+// If app is not valid according to [ValidateApp], such as "..", nothing is searched and it returns "" and false.
 //
 // Parameters:
 //   - app: The application name to search configurations for
@@ -30,61 +42,444 @@ import (
 //   - dir: The configuration directory path
 //   - exist: Boolean indicating whether the directory exists on the filesystem
 func Dir(app string) (dir string, exist bool) {
+	dir, status := DirStatus(app)
+	return dir, status == FileExists
+}
+
+// DirWith is like [Dir] but accepts options that modify its behavior.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - opts: The options to apply
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirWith(app string, opts ...Option) (dir string, exist bool) {
+	o := newOptions(opts)
+	l := o.layout()
+	candidates, local := l.dirs(o.app(app))
+	dir, status, _ := searchDirProbe(context.Background(), o.matchCase(candidates), local, o.dirExists())
+	exist = status == FileExists
+	if exist {
+		dir = o.resolve(dir)
+	} else if o.deepest {
+		if best := fewestMissing(l.searched().locations(o.app(app)), func(dir string) string { return dir }); best != "" {
+			dir = best
+		}
+	}
+	return o.abs(dir), exist
+}
+
+// DirUnder is like [Dir] but searches as if base were the home directory,
+// for applications that find their configuration mounted at a known root, such as in a container.
+//
+// The function tries the following locations in order:
+//
+//  1. <base>/.config/<app>
+//  2. <base>/lib/<app> (on Plan9 only, see [WithPlan9Compat])
+//  3. <base>/.<app>
+//
+// XDG_CONFIG_HOME, the Snap location and the current directory are not consulted,
+// so the result is always under base.
+// If no existing directory is found, it returns the first location and false.
+//
+// Parameters:
+//   - base: The directory used in place of the home directory
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirUnder(base, app string) (dir string, exist bool) {
+	l := layout{
+		xdgHome: func() string { return "" },
+		home:    func() (string, error) { return base, nil },
+		rel:     ".config",
+		order:   []Location{DotConfig, Plan9Lib, HomeDot},
+		plan9:   plan9Lib,
+	}
+	dir, status := searchDir(l.dirs(app))
+	return dir, status == FileExists
+}
+
+// DirInRoots searches for a configuration directory of the specified application only under the given roots,
+// ignoring XDG_CONFIG_HOME, the home directory and the current directory entirely.
+// It gives embedders complete control over where configuration lives, such as a fixed set of mounted volumes.
+//
+// It returns the first existing <root>/<app> in the order of roots, or the one under the first root and false
+// if none exists. If roots is empty, or app is not valid according to [ValidateApp], it returns "" and false,
+// so that a name such as ".." can never reach outside the roots.
+//
+// Parameters:
+//   - roots: The directories to search, in order of preference
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirInRoots(roots []string, app string) (dir string, exist bool) {
+	app = normalizeApp(app)
+	if ValidateApp(app) != nil {
+		return "", false
+	}
+	candidates := func(yield func(string) bool) {
+		for _, root := range roots {
+			if !yield(filepath.Join(root, app)) {
+				return
+			}
+		}
+	}
+	dir, status := searchDir(candidates, "")
+	return dir, status == FileExists
+}
+
+// DirStatus searches for a configuration directory for the specified application
+// in the same order as [Dir], but reports a three-state status like [File].
+//
+// If an existing directory is found, it returns the directory path and [FileExists].
+// If no existing directory is found, it returns the same fallback path as [Dir] along with
+// [BaseExists] when the parent of that path exists, or [NotExists] otherwise.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - status: A FileStatus value indicating whether the directory exists, only its parent exists, or neither exists
+func DirStatus(app string) (dir string, status FileStatus) {
+	return searchDir(list(app), localDir(app))
+}
+
+// DirResult is like [Dir] but also reports the error of [os.UserHomeDir] when the home
+// directory was needed during the search and could not be determined.
+// This lets callers tell a missing configuration apart from a search that had to skip the
+// home-based locations, and log something like "couldn't determine home directory, using local fallback".
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+//   - homeErr: The error of [os.UserHomeDir] if it was consulted and failed, or nil
+func DirResult(app string) (dir string, exist bool, homeErr error) {
+	l := configLayout()
+	l.home = func() (string, error) {
+		home, err := homeDir()
+		homeErr = err
+		return home, err
+	}
+	candidates, local := l.dirs(app)
+	dir, status := searchDir(candidates, local)
+	return dir, status == FileExists, homeErr
+}
+
+// DirRank is like [Dir] but also reports the zero-based position of the returned directory
+// among the candidates that [ListDirs] yields, so that tooling can report "found at priority 3 of 5".
+//
+// When no existing directory is found, the returned fallback is the first candidate and rank is 0.
+// When the current-directory fallback, which is outside the candidates, is returned, rank is -1.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+//   - rank: The position of dir among the candidates, or -1 for the current-directory fallback
+func DirRank(app string) (dir string, exist bool, rank int) {
+	rank = -1
+	i := 0
+	for candidate := range list(app) {
+		if dirExists(candidate) {
+			return candidate, true, i
+		}
+		if rank < 0 {
+			dir, rank = candidate, i
+		}
+		i++
+	}
+	if rank < 0 {
+		dir = localDir(app)
+	}
+	return dir, dirExists(dir), rank
+}
+
+// DirContext is like [Dir] but checks ctx before each existence probe,
+// so that a search on a slow filesystem can be abandoned.
+//
+// If ctx is cancelled during the search, it returns ctx.Err().
+//
+// Parameters:
+//   - ctx: The context controlling cancellation of the search
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+//   - err: The context error if the search was cancelled
+func DirContext(ctx context.Context, app string) (dir string, exist bool, err error) {
+	dir, status, err := searchDirContext(ctx, list(app), localDir(app))
+	if err != nil {
+		return "", false, err
+	}
+	return dir, status == FileExists, nil
+}
+
+// searchDir returns the first existing directory among candidates.
+// If candidates is empty, local is used as the last resort unless it is empty too.
+func searchDir(candidates iter.Seq[string], local string) (dir string, status FileStatus) {
+	dir, status, _ = searchDirContext(context.Background(), candidates, local)
+	return dir, status
+}
+
+func searchDirContext(ctx context.Context, candidates iter.Seq[string], local string) (dir string, status FileStatus, err error) {
+	return searchDirProbe(ctx, candidates, local, dirExists)
+}
+
+// searchDirProbe is like searchDirContext but uses exists to probe each candidate.
+func searchDirProbe(ctx context.Context, candidates iter.Seq[string], local string, exists func(string) bool) (dir string, status FileStatus, err error) {
 	var fallback string
-	for dir := range list(app) {
-		if dirExists(dir) {
-			return dir, true
+	for dir := range candidates {
+		if err := ctx.Err(); err != nil {
+			return "", NotExists, err
+		}
+		if exists(dir) {
+			return dir, FileExists, nil
 		}
 		if fallback == "" {
 			fallback = dir
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return "", NotExists, err
+	}
 	if fallback == "" {
-		dir = "." + app
-		return dir, dirExists(dir)
+		if local == "" {
+			return "", NotExists, nil
+		}
+		fallback = local
+		if exists(fallback) {
+			return fallback, FileExists, nil
+		}
 	}
-	return fallback, false
+	return fallback, checkDir(fallback), nil
 }
 
-func list(app string) iter.Seq[string] {
+// checkDir reports whether dir exists, only its parent exists, or neither exists.
+func checkDir(dir string) FileStatus {
+	if dirExists(dir) {
+		return FileExists
+	}
+	if dirExists(filepath.Dir(dir)) {
+		return BaseExists
+	}
+	return NotExists
+}
+
+// DirAll returns every existing configuration directory for the specified application,
+// in the search order of [Dir].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - The existing configuration directory paths
+func DirAll(app string) []string {
+	var dirs []string
+	for dir := range considered(app) {
+		if dirExists(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// considered yields every directory that [Dir] considers for app,
+// including the current-directory fallback when no other candidate could be determined.
+func considered(app string) iter.Seq[string] {
+	candidates, local := configLayout().dirs(app)
 	return func(yield func(string) bool) {
-		if xdg := xdgConfigHome(); xdg != "" {
-			listWithXDG(yield, app, xdg)
-		} else {
-			listWithNoXDG(yield, app)
+		empty := true
+		for dir := range candidates {
+			empty = false
+			if !yield(dir) {
+				return
+			}
+		}
+		if empty && local != "" {
+			yield(local)
 		}
 	}
 }
 
-func listWithXDG(yield func(string) bool, app, xdg string) {
-	if yield(filepath.Join(xdg, app)) {
-		if home, err := userHomeDir(); err == nil { // if NO error
-			listHome(yield, home, app)
+// ListDirs returns an iterator over the candidate configuration directories
+// for the specified application, in the same order that [Dir] searches them.
+//
+// Unlike [Dir], it does not check whether the directories exist, which allows
+// callers to implement their own existence logic. The current-directory
+// fallback ".<app>" is not included.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - An iterator yielding candidate directory paths
+func ListDirs(app string) iter.Seq[string] {
+	return list(app)
+}
+
+// Candidates is like [ListDirs] but returns the candidate directories as a slice,
+// which is convenient for logging and for APIs that expect a []string.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - The candidate directory paths in search order
+func Candidates(app string) []string {
+	return slices.Collect(ListDirs(app))
+}
+
+// ListDirStatus returns an iterator over the same candidate directories as [ListDirs],
+// each paired with its existence status: [FileExists] when the directory exists,
+// [BaseExists] when only its parent exists, and [NotExists] otherwise.
+//
+// The statuses are determined with the same check that [Dir] uses.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - An iterator yielding candidate directory paths and their statuses
+func ListDirStatus(app string) iter.Seq2[string, FileStatus] {
+	return func(yield func(string, FileStatus) bool) {
+		for dir := range list(app) {
+			if !yield(dir, checkDir(dir)) {
+				return
+			}
 		}
 	}
 }
 
-func listWithNoXDG(yield func(string) bool, app string) {
-	if home, err := userHomeDir(); err == nil { // if NO error
-		if yield(filepath.Join(home, ".config", app)) {
-			listHome(yield, home, app)
+// DirCandidates returns an iterator over every directory that [Dir] considers for the specified application,
+// in search order, each paired with whether it exists.
+//
+// Unlike [Dir], it never stops at an existing directory by itself; the consumer decides when to stop.
+// Unlike [ListDirs], it includes the current-directory fallback ".<app>" when it is the only candidate,
+// as it is in [Explain].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - An iterator yielding candidate directory paths and whether they exist
+func DirCandidates(app string) iter.Seq2[string, bool] {
+	return func(yield func(string, bool) bool) {
+		for dir := range considered(app) {
+			if !yield(dir, dirExists(dir)) {
+				return
+			}
 		}
 	}
 }
 
-func listHome(yield func(string) bool, home, app string) {
-	if yield(filepath.Join(home, "lib", app)) {
-		yield(filepath.Join(home, "."+app))
+func list(app string) iter.Seq[string] {
+	candidates, _ := configLayout().dirs(app)
+	return candidates
+}
+
+// localDir returns the current-directory fallback .<app>,
+// or "" if app is not a valid application name and thus has no fallback.
+func localDir(app string) string {
+	if ValidateApp(normalizeApp(app)) != nil {
+		return ""
 	}
+	return dotName(app)
+}
+
+// listIn yields the candidate directories for app under the XDG base directory
+// returned by xdgHome, or under $HOME/<rel> when it is not set.
+func listIn(app string, xdgHome func() string, rel string) iter.Seq[string] {
+	candidates, _ := layout{xdgHome: xdgHome, rel: rel, order: DefaultSearchOrder(), plan9: plan9Lib}.dirs(app)
+	return candidates
 }
 
+// xdgConfigHome returns the XDG base directory of configurations.
+// Like userHomeDir, it is a test hook: only tests replace it, before any search starts.
 var xdgConfigHome = func() string {
-	return os.Getenv("XDG_CONFIG_HOME")
+	return currentResolver.Load().xdgConfigHome()
+}
+
+func defaultXDGConfigHome() string {
+	return xdgEnv("XDG_CONFIG_HOME")
+}
+
+// xdgEnv returns the value of the XDG base directory variable key,
+// or "" if it is unset or not an absolute path, which the XDG Base Directory Specification
+// says must be ignored.
+// A leading "~" left unexpanded by the shell is replaced with the home directory first,
+// and the result is cleaned with [filepath.Clean], so that a trailing separator does not
+// make the same directory compare differently.
+func xdgEnv(key string) string {
+	dir := os.Getenv(key)
+	if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, "~"+string(os.PathSeparator)) {
+		home, err := homeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, dir[1:])
+	}
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	return filepath.Clean(dir)
 }
 
-var dirExists = func(dir string) bool {
+// DirExists reports whether dir exists and is a directory.
+// It uses the same existence semantics as [Dir].
+func DirExists(dir string) bool {
 	info, err := os.Stat(dir)
 	return err == nil && info.IsDir()
 }
 
-var userHomeDir = os.UserHomeDir
+// dirExists is a test hook for [DirExists]; only tests replace it, before any search starts.
+var dirExists = DirExists
+
+// ProbeDir reports whether dir exists, only its parent exists, or neither exists, like [ListDirStatus],
+// but reports [PermissionDenied] when dir or its parent cannot be examined for lack of permission,
+// so that a configuration directory that exists but is not accessible is not mistaken for a missing one.
+//
+// It can be combined with [ListDirs] to report such candidates.
+func ProbeDir(dir string) FileStatus {
+	info, err := os.Stat(dir)
+	if err == nil && info.IsDir() {
+		return FileExists
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return PermissionDenied
+	}
+	return probeBase(filepath.Dir(dir))
+}
+
+// userHomeDir returns the user's home directory, as installed by [SetHomeDirFunc].
+// It is a test hook: only tests replace it, before any search starts.
+// Options such as [WithHome] never replace it; they are applied to the layout of a single search.
+var userHomeDir = func() (string, error) {
+	return currentResolver.Load().homeDir()
+}
+
+// homeDir is like userHomeDir but reports an empty home directory or the filesystem root,
+// as set in some misconfigured containers, as unavailable, so that no candidate such as
+// /.<app> is created at the root of the filesystem.
+func homeDir() (string, error) {
+	home, err := userHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if clean := filepath.Clean(home); home == "" || filepath.Dir(clean) == clean {
+		return "", fmt.Errorf("dotconfig: unusable home directory %q", home)
+	}
+	return home, nil
+}
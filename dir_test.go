@@ -1,7 +1,11 @@
 package dotconfig
 
 import (
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -174,7 +178,7 @@ func TestListXDG(t *testing.T) {
 		return "/mock/home", nil
 	}
 
-	listWithXDG(yield, "myapp", "/mock/xdg")
+	listIn("myapp", func() string { return "/mock/xdg" }, ".config")(yield)
 
 	if called != 3 {
 		t.Errorf("Expected yield to be called 3 times, got %d", called)
@@ -216,7 +220,7 @@ func TestListHome(t *testing.T) {
 		return "/mock/home", nil
 	}
 
-	listWithNoXDG(yield, "myapp")
+	listIn("myapp", func() string { return "" }, ".config")(yield)
 
 	if called != 3 {
 		t.Errorf("Expected yield to be called 3 times, got %d", called)
@@ -256,7 +260,7 @@ func TestListHomeEarlyTermination(t *testing.T) {
 		return "/mock/home", nil
 	}
 
-	listWithNoXDG(yield, "myapp")
+	listIn("myapp", func() string { return "" }, ".config")(yield)
 
 	if called != 1 {
 		t.Errorf("Expected yield to be called 1 time, got %d", called)
@@ -280,7 +284,7 @@ func TestListXDGEarlyTermination(t *testing.T) {
 		return "/mock/home", nil
 	}
 
-	listWithXDG(yield, "myapp", "/mock/xdg")
+	listIn("myapp", func() string { return "/mock/xdg" }, ".config")(yield)
 
 	if called != 1 {
 		t.Errorf("Expected yield to be called 1 time, got %d", called)
@@ -364,7 +368,7 @@ func TestList(t *testing.T) {
 }
 
 func TestXdgConfigHome(t *testing.T) {
-	expected := "/mock/xdg"
+	expected := t.TempDir()
 	t.Setenv("XDG_CONFIG_HOME", expected)
 	got := xdgConfigHome()
 	if got != expected {
@@ -372,6 +376,88 @@ func TestXdgConfigHome(t *testing.T) {
 	}
 }
 
+func TestXdgConfigHomeTrailingSeparator(t *testing.T) {
+	expected := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", expected+string(filepath.Separator))
+	if got := xdgConfigHome(); got != expected {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	candidates := Candidates("myapp")
+	t.Setenv("XDG_CONFIG_HOME", expected)
+	if withoutSeparator := Candidates("myapp"); !slices.Equal(candidates, withoutSeparator) {
+		t.Errorf("Expected %v, got %v", withoutSeparator, candidates)
+	}
+}
+
+func TestXdgConfigHomeRelative(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "relative/path")
+	if got := xdgConfigHome(); got != "" {
+		t.Errorf("Expected relative XDG_CONFIG_HOME to be ignored, got '%s'", got)
+	}
+
+	// Save original functions to restore later
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	dirExists = func(dir string) bool { return false }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	dir, _ := Dir("myapp")
+	expected := filepath.Join("/mock/home", ".config", "myapp")
+	if dir != expected {
+		t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+	}
+}
+
+func TestXdgConfigHomeTilde(t *testing.T) {
+	// Save original functions to restore later
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	home := t.TempDir()
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	testCases := []struct {
+		Value    string
+		Expected string
+	}{
+		{"~/config", filepath.Join(home, "config")},
+		{"~", home},
+		{"~user/config", ""},
+	}
+	for _, tc := range testCases {
+		t.Setenv("XDG_CONFIG_HOME", tc.Value)
+		if got := xdgConfigHome(); got != tc.Expected {
+			t.Errorf("Expected %q for %q, got %q", tc.Expected, tc.Value, got)
+		}
+	}
+
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+	t.Setenv("XDG_CONFIG_HOME", "~/config")
+	if got := xdgConfigHome(); got != "" {
+		t.Errorf("Expected '' when home is unavailable, got '%s'", got)
+	}
+}
+
 func TestDirExists(t *testing.T) {
 	if !dirExists(".") {
 		t.Errorf("Expected true, got false")
@@ -380,3 +466,853 @@ func TestDirExists(t *testing.T) {
 		t.Errorf("Expected false, got true")
 	}
 }
+
+func TestListDirs(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	expectedPaths := []string{}
+	for path := range list("myapp") {
+		expectedPaths = append(expectedPaths, path)
+	}
+
+	paths := []string{}
+	for path := range ListDirs("myapp") {
+		paths = append(paths, path)
+	}
+
+	if len(paths) != len(expectedPaths) {
+		t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+	}
+
+	for i, expected := range expectedPaths {
+		if i >= len(paths) {
+			t.Errorf("Missing expected path at index %d: %s", i, expected)
+			continue
+		}
+		if paths[i] != expected {
+			t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+		}
+	}
+}
+
+func TestDirStatus(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("XDG config exists", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, status := DirStatus("myapp")
+
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("No directories exist, parent of first option exists", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, status := DirStatus("myapp")
+
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+
+	t.Run("No directories exist, parent of first option not exist", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, status := DirStatus("myapp")
+
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("No locations available, local dir not exist", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == "."
+		}
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		dir, status := DirStatus("myapp")
+
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp', got '%s'", dir)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+}
+
+func TestDirResult(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	homeErr := errors.New("no home")
+
+	t.Run("home unavailable, local fallback", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "", homeErr
+		}
+
+		dir, exist, err := DirResult("myapp")
+
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+		if !errors.Is(err, homeErr) {
+			t.Errorf("Expected home error, got %v", err)
+		}
+	})
+
+	t.Run("home unavailable, XDG config exists", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg/myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "", homeErr
+		}
+
+		dir, exist, err := DirResult("myapp")
+
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+		if err != nil {
+			t.Errorf("Expected no error when home was not consulted, got %v", err)
+		}
+	})
+
+	t.Run("home available", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, _, err := DirResult("myapp")
+
+		if dir != "/mock/home/.config/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+		}
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestListNamespaced(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	paths := []string{}
+	for path := range list("acme/tool") {
+		paths = append(paths, path)
+	}
+
+	expectedPaths := []string{
+		"/mock/home/.config/acme/tool",
+		"/mock/home/lib/acme/tool",
+		"/mock/home/.acme-tool",
+	}
+
+	if len(paths) != len(expectedPaths) {
+		t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+	}
+
+	for i, expected := range expectedPaths {
+		if i >= len(paths) {
+			t.Errorf("Missing expected path at index %d: %s", i, expected)
+			continue
+		}
+		if paths[i] != expected {
+			t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+		}
+	}
+}
+
+func TestDirWith(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	xdg := filepath.Join(tmp, "xdg")
+	if err := os.MkdirAll(filepath.Join(tmp, "real"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(xdg, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tmp, "real"), filepath.Join(xdg, "myapp")); err != nil {
+		t.Skip(err)
+	}
+
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("without options", func(t *testing.T) {
+		dir, exist := DirWith("myapp")
+
+		expected := filepath.Join(xdg, "myapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("resolve symlinks", func(t *testing.T) {
+		dir, exist := DirWith("myapp", WithResolveSymlinks())
+
+		expected := filepath.Join(tmp, "real")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("resolve symlinks of missing dir", func(t *testing.T) {
+		dir, exist := DirWith("otherapp", WithResolveSymlinks())
+
+		expected := filepath.Join(xdg, "otherapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestDirContext(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("not cancelled", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.myapp"
+		}
+
+		dir, exist, err := DirContext(context.Background(), "myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("cancelled mid-search", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		probed := 0
+		dirExists = func(dir string) bool {
+			probed++
+			cancel()
+			return false
+		}
+
+		dir, exist, err := DirContext(ctx, "myapp")
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if dir != "" {
+			t.Errorf("Expected dir to be empty, got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+		if probed != 1 {
+			t.Errorf("Expected dirExists to be called 1 time, got %d", probed)
+		}
+	})
+}
+
+func TestDirExistsExported(t *testing.T) {
+	if !DirExists(".") {
+		t.Errorf("Expected true, got false")
+	}
+	if DirExists("dir_test.go") {
+		t.Errorf("Expected false, got true")
+	}
+}
+
+func TestDirAll(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("with home", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg/myapp" || dir == "/mock/home/.myapp" || dir == ".myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		expected := []string{"/mock/xdg/myapp", "/mock/home/.myapp"}
+		if got := DirAll("myapp"); !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("without home", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool {
+			return dir == ".myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		expected := []string{".myapp"}
+		if got := DirAll("myapp"); !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestDirNormalizeApp(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/.myapp"
+	}
+
+	t.Run("with home", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		expected := slices.Collect(list("myapp"))
+		for _, app := range []string{"myapp/", "/myapp"} {
+			if got := slices.Collect(list(app)); !slices.Equal(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		}
+
+		dir, exist := Dir("myapp/")
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("without home", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		dir, _ := Dir("myapp/")
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp', got '%s'", dir)
+		}
+	})
+}
+
+func TestListDirStatus(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/xdg" || dir == "/mock/home/.myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	paths := []string{}
+	statuses := []FileStatus{}
+	for path, status := range ListDirStatus("myapp") {
+		paths = append(paths, path)
+		statuses = append(statuses, status)
+	}
+
+	expectedPaths := []string{
+		"/mock/xdg/myapp",
+		"/mock/home/lib/myapp",
+		"/mock/home/.myapp",
+	}
+	expectedStatuses := []FileStatus{BaseExists, NotExists, FileExists}
+	if !slices.Equal(paths, expectedPaths) {
+		t.Errorf("Expected %v, got %v", expectedPaths, paths)
+	}
+	if !slices.Equal(statuses, expectedStatuses) {
+		t.Errorf("Expected %v, got %v", expectedStatuses, statuses)
+	}
+
+	called := 0
+	for range ListDirStatus("myapp") {
+		called++
+		break
+	}
+	if called != 1 {
+		t.Errorf("Expected yield to be called 1 time, got %d", called)
+	}
+}
+
+func TestDirUnder(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	// The real XDG and home directories must not be consulted
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("base .config exists", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mnt/root/.config/myapp" || dir == "/mnt/root/.myapp"
+		}
+
+		dir, exist := DirUnder("/mnt/root", "myapp")
+
+		if dir != "/mnt/root/.config/myapp" {
+			t.Errorf("Expected dir to be '/mnt/root/.config/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No .config, fallback to base dot dir", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mnt/root/.myapp"
+		}
+
+		dir, exist := DirUnder("/mnt/root", "myapp")
+
+		if dir != "/mnt/root/.myapp" {
+			t.Errorf("Expected dir to be '/mnt/root/.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No directories exist, fallback to first option", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg/myapp" || dir == ".myapp"
+		}
+
+		dir, exist := DirUnder("/mnt/root", "myapp")
+
+		if dir != "/mnt/root/.config/myapp" {
+			t.Errorf("Expected dir to be '/mnt/root/.config/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestDirRank(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+
+	testCases := []struct {
+		Exists   string
+		Dir      string
+		Exist    bool
+		Expected int
+	}{
+		{"/mock/xdg/myapp", "/mock/xdg/myapp", true, 0},
+		{"/mock/home/lib/myapp", "/mock/home/lib/myapp", true, 1},
+		{"/mock/home/.myapp", "/mock/home/.myapp", true, 2},
+		{"", "/mock/xdg/myapp", false, 0},
+	}
+
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	for _, tc := range testCases {
+		dirExists = func(dir string) bool { return dir == tc.Exists }
+
+		dir, exist, rank := DirRank("myapp")
+
+		if dir != tc.Dir || exist != tc.Exist || rank != tc.Expected {
+			t.Errorf("Expected '%s', %v, %d, got '%s', %v, %d", tc.Dir, tc.Exist, tc.Expected, dir, exist, rank)
+		}
+	}
+
+	t.Run("current-directory fallback", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+		dirExists = func(dir string) bool { return dir == ".myapp" }
+
+		dir, exist, rank := DirRank("myapp")
+
+		if dir != ".myapp" || !exist || rank != -1 {
+			t.Errorf("Expected '.myapp', true, -1, got '%s', %v, %d", dir, exist, rank)
+		}
+	})
+}
+
+func TestDirInRoots(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	// The XDG and home directories must not be consulted
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	dirExists = func(dir string) bool {
+		return dir == "/mnt/b/myapp" || dir == "/mock/xdg/myapp" || dir == ".myapp"
+	}
+
+	testCases := []struct {
+		Roots    []string
+		Expected string
+		Exist    bool
+	}{
+		{[]string{"/mnt/a", "/mnt/b"}, "/mnt/b/myapp", true},
+		{[]string{"/mnt/a", "/mnt/c"}, "/mnt/a/myapp", false},
+		{nil, "", false},
+	}
+
+	for _, tc := range testCases {
+		dir, exist := DirInRoots(tc.Roots, "myapp")
+		if dir != tc.Expected || exist != tc.Exist {
+			t.Errorf("Expected '%s', %v for %v, got '%s', %v", tc.Expected, tc.Exist, tc.Roots, dir, exist)
+		}
+	}
+
+	// An invalid name must not escape the roots.
+	for _, app := range []string{"..", "../../b/myapp", ""} {
+		if dir, exist := DirInRoots([]string{"/mnt/a/x"}, app); dir != "" || exist {
+			t.Errorf("Expected '', false for %q, got '%s', %v", app, dir, exist)
+		}
+	}
+}
+
+func TestDirCandidates(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/xdg/myapp" || dir == "/mock/home/.myapp"
+	}
+
+	type candidate struct {
+		Dir   string
+		Exist bool
+	}
+
+	t.Run("with home", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		var got []candidate
+		for dir, exist := range DirCandidates("myapp") {
+			got = append(got, candidate{dir, exist})
+		}
+
+		// An existing directory does not stop the iteration.
+		expected := []candidate{
+			{"/mock/xdg/myapp", true},
+			{"/mock/home/lib/myapp", false},
+			{"/mock/home/.myapp", true},
+		}
+		if !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("early termination", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		var got []string
+		for dir := range DirCandidates("myapp") {
+			got = append(got, dir)
+			break
+		}
+
+		if expected := []string{"/mock/xdg/myapp"}; !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("current-directory fallback", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		var got []candidate
+		for dir, exist := range DirCandidates("myapp") {
+			got = append(got, candidate{dir, exist})
+		}
+
+		if expected := []candidate{{".myapp", false}}; !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestDirRootHome(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool { return false }
+
+	for _, home := range []string{"/", ""} {
+		userHomeDir = func() (string, error) {
+			return home, nil
+		}
+
+		dir, _ := Dir("myapp")
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp' for home %q, got '%s'", home, dir)
+		}
+		for file := range ListFiles("myapp", "config.yaml") {
+			if filepath.IsAbs(file) {
+				t.Errorf("Expected no candidate under home %q, got '%s'", home, file)
+			}
+		}
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	expected := []string{"/mock/xdg/myapp", "/mock/home/lib/myapp", "/mock/home/.myapp"}
+	if got := Candidates("myapp"); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	expected = []string{
+		"/mock/xdg/myapp/config.yaml",
+		"/mock/home/lib/myapp/config.yaml",
+		"/mock/home/.myapp/config.yaml",
+		"/mock/home/.myapp.yaml",
+	}
+	if got := FileCandidates("myapp", "config.yaml"); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
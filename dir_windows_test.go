@@ -0,0 +1,42 @@
+//go:build windows
+
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirXDGOnWindows(t *testing.T) {
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	if err := os.MkdirAll(filepath.Join(xdg, "myapp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	dir, exist := Dir("myapp")
+
+	expected := filepath.Join(xdg, "myapp")
+	if dir != expected {
+		t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+	}
+	if !exist {
+		t.Error("Expected exist to be true")
+	}
+
+	path, _ := File("myapp", "config.yaml")
+	if expected := filepath.Join(xdg, "myapp", "config.yaml"); path != expected {
+		t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+	}
+}
+
+func TestDirXDGRelativeOnWindows(t *testing.T) {
+	// A drive-relative path is not absolute and must be ignored.
+	t.Setenv("XDG_CONFIG_HOME", `\config`)
+
+	if got := xdgConfigHome(); got != "" {
+		t.Errorf("Expected drive-relative XDG_CONFIG_HOME to be ignored, got '%s'", got)
+	}
+}
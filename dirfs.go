@@ -0,0 +1,33 @@
+package dotconfig
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DirFS returns a file system rooted at the configuration directory of the specified application,
+// located using [Dir], along with the absolute path of that directory.
+// This lets callers use [fs.WalkDir], [fs.ReadFile] and the like over the configuration tree.
+//
+// If the directory does not exist, it returns an [*fs.PathError] wrapping [fs.ErrNotExist]
+// with the path that [Dir] returned.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - fsys: The file system rooted at the configuration directory, as returned by [os.DirFS]
+//   - dir: The absolute configuration directory path
+//   - err: An error if the directory does not exist or its absolute path could not be determined
+func DirFS(app string) (fsys fs.FS, dir string, err error) {
+	dir, exist := Dir(app)
+	if !exist {
+		return nil, dir, &fs.PathError{Op: "open", Path: dir, Err: fs.ErrNotExist}
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, dir, err
+	}
+	return os.DirFS(dir), dir, nil
+}
@@ -0,0 +1,68 @@
+package dotconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirFS(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("directory not exists", func(t *testing.T) {
+		fsys, dir, err := DirFS("myapp")
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected fs.ErrNotExist, got %v", err)
+		}
+		if fsys != nil {
+			t.Error("Expected fsys to be nil")
+		}
+		expected := filepath.Join(xdg, "myapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+	})
+
+	t.Run("directory exists", func(t *testing.T) {
+		expected := filepath.Join(xdg, "myapp")
+		if err := os.MkdirAll(filepath.Join(expected, "conf.d"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(expected, "conf.d", "a.conf"), []byte("a"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		fsys, dir, err := DirFS("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		data, err := fs.ReadFile(fsys, "conf.d/a.conf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "a" {
+			t.Errorf("Expected data to be 'a', got '%s'", data)
+		}
+	})
+}
@@ -7,7 +7,7 @@
 // more flexibility and better compatibility with existing applications and different
 // environment configurations, including Plan9.
 //
-// It implements the XDG Base Directory Specification (when XDG_CONFIG_HOME is set)
+// It implements the XDG Base Directory Specification (when XDG_CONFIG_HOME is set to an absolute path)
 // and falls back to traditional home directory locations. The package's primary functions
 // are [Dir], which locates the appropriate configuration directory for an application,
 // and [File], which locates specific configuration files.
@@ -16,7 +16,7 @@
 //
 //  1. $XDG_CONFIG_HOME/<app> (if XDG_CONFIG_HOME is set)
 //  2. $HOME/.config/<app> (if XDG_CONFIG_HOME is not set)
-//  3. $HOME/lib/<app> (for Plan9 compatibility)
+//  3. $HOME/lib/<app> (on Plan9 only, see [WithPlan9Compat])
 //  4. $HOME/.<app> (if [os.UserHomeDir] returns no error)
 //  5. .<app> (in current directory, as last resort)
 //
@@ -25,12 +25,25 @@
 //
 //  1. $XDG_CONFIG_HOME/<app>/<name> (if XDG_CONFIG_HOME is set)
 //  2. $HOME/.config/<app>/<name> (if XDG_CONFIG_HOME is not set)
-//  3. $HOME/lib/<app>/<name> (for Plan9 compatibility)
+//  3. $HOME/lib/<app>/<name> (on Plan9 only, see [WithPlan9Compat])
 //  4. $HOME/.<app>/<name> (if [os.UserHomeDir] returns no error)
 //  5. $HOME/.<app><ext> (where <ext> is the file extension of <name>)
-//  6. .<app>/<name> (in current directory)
+//  6. .<app>/<name> (in current directory, if no other location could be determined)
 //  7. .<app><ext> (in current directory, as last resort)
 //
+// When running inside a Snap, that is, when SNAP and SNAP_USER_COMMON are set,
+// $SNAP_USER_COMMON/<app> is searched before all of these.
+//
+// XDG_CONFIG_HOME is honored on every operating system, including Windows,
+// so that a cross-platform tool can set it there too.
+//
+// An application name may be namespaced with "/", as in "acme/tool". The nested form is kept
+// for directory locations such as $XDG_CONFIG_HOME/acme/tool, while dot-prefixed locations
+// flatten it into a single segment by replacing "/" with "-", as in $HOME/.acme-tool.
+//
+// Leading and trailing separators of an application name are ignored, so "myapp/" is the same
+// as "myapp". Use [ValidateApp] to reject such names instead.
+//
 // Unlike [os.UserConfigDir] which only returns a single directory recommendation,
 // this package actively searches for existing configuration directories and files, providing
 // a recommended path even when no directory or file exists yet, making it easier to handle
@@ -0,0 +1,46 @@
+// Package dotconfigtest provides helpers for testing code that locates its configuration
+// with the dotconfig package, against a sandbox instead of the real home directory.
+package dotconfigtest
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// WithTempEnv creates a temporary home directory and a temporary XDG_CONFIG_HOME for the test,
+// and points the environment at them with [testing.T.Setenv], so that dotconfig functions
+// such as Dir and File search only the sandbox. Running inside a Snap is disabled as well.
+//
+// The directories are removed and the environment is restored when the test finishes.
+// Since it uses [testing.T.Setenv], it cannot be used in parallel tests.
+//
+// Parameters:
+//   - t: The test using the sandbox
+//
+// Returns:
+//   - home: The temporary home directory
+//   - xdg: The temporary XDG_CONFIG_HOME directory
+func WithTempEnv(t *testing.T) (home, xdg string) {
+	t.Helper()
+	root := t.TempDir()
+	home = filepath.Join(root, "home")
+	xdg = filepath.Join(root, "xdg")
+	for _, dir := range []string{home, xdg} {
+		if err := os.Mkdir(dir, 0700); err != nil {
+			t.Fatalf("dotconfigtest: %v", err)
+		}
+	}
+	switch runtime.GOOS {
+	case "windows":
+		t.Setenv("USERPROFILE", home)
+	case "plan9":
+		t.Setenv("home", home)
+	default:
+		t.Setenv("HOME", home)
+	}
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Setenv("SNAP", "")
+	return home, xdg
+}
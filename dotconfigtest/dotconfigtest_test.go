@@ -0,0 +1,41 @@
+package dotconfigtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goaux/dotconfig"
+	"github.com/goaux/dotconfig/dotconfigtest"
+)
+
+func TestWithTempEnv(t *testing.T) {
+	home, xdg := dotconfigtest.WithTempEnv(t)
+
+	if got, err := os.UserHomeDir(); err != nil || got != home {
+		t.Errorf("Expected home directory to be '%s', got '%s' (%v)", home, got, err)
+	}
+	if got := os.Getenv("XDG_CONFIG_HOME"); got != xdg {
+		t.Errorf("Expected XDG_CONFIG_HOME to be '%s', got '%s'", xdg, got)
+	}
+
+	dir, exist := dotconfig.Dir("myapp")
+	if expected := filepath.Join(xdg, "myapp"); dir != expected {
+		t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+	}
+	if exist {
+		t.Error("Expected exist to be false")
+	}
+
+	legacy := filepath.Join(home, ".myapp")
+	if err := os.Mkdir(legacy, 0700); err != nil {
+		t.Fatal(err)
+	}
+	dir, exist = dotconfig.Dir("myapp")
+	if dir != legacy {
+		t.Errorf("Expected dir to be '%s', got '%s'", legacy, dir)
+	}
+	if !exist {
+		t.Error("Expected exist to be true")
+	}
+}
@@ -0,0 +1,76 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DirEnv is like [Dir] but lets an application-specific environment variable,
+// such as MYAPP_CONFIG_DIR, take absolute precedence.
+//
+// If the environment variable named envVar is set to a non-empty value, that value is
+// returned as the configuration directory without consulting any other location.
+// Otherwise it delegates to [Dir].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - envVar: The name of the environment variable overriding the directory
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirEnv(app, envVar string) (dir string, exist bool) {
+	if dir := os.Getenv(envVar); dir != "" {
+		return dir, dirExists(dir)
+	}
+	return Dir(app)
+}
+
+// FileEnv is like [File] but lets an application-specific environment variable,
+// such as MYAPP_CONFIG_DIR, name the configuration directory with absolute precedence.
+//
+// If the environment variable named envVar is set to a non-empty value, the file name
+// is joined to that directory without consulting any other location.
+// Otherwise it delegates to [File].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//   - envVar: The name of the environment variable overriding the directory
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileEnv(app, name, envVar string) (path string, status FileStatus) {
+	if dir := os.Getenv(envVar); dir != "" {
+		path := filepath.Join(dir, newFileConfig(app, name).File)
+		return path, checkFile(path)
+	}
+	return File(app, name)
+}
+
+// FileEnvPath is like [File] but lets an application-specific environment variable,
+// such as MYAPP_CONFIG, name the configuration file itself with absolute precedence.
+//
+// If the environment variable named envVar is set to a non-empty value, that path is returned
+// along with its status without consulting any other location. A relative path is resolved
+// against the current directory, so the result does not change if the process later changes directory.
+// Otherwise it delegates to [File].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//   - envVar: The name of the environment variable overriding the file path
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileEnvPath(app, name, envVar string) (path string, status FileStatus) {
+	if path := os.Getenv(envVar); path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return path, checkFile(path)
+	}
+	return File(app, name)
+}
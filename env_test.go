@@ -0,0 +1,171 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirEnv(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/xdg/myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("env var set", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG_DIR", "/mock/override")
+
+		dir, exist := DirEnv("myapp", "MYAPP_CONFIG_DIR")
+
+		if dir != "/mock/override" {
+			t.Errorf("Expected dir to be '/mock/override', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("env var empty", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG_DIR", "")
+
+		dir, exist := DirEnv("myapp", "MYAPP_CONFIG_DIR")
+
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+}
+
+func TestFileEnv(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	checkFile = func(path string) FileStatus {
+		if path == "/mock/override/config.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("env var set", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG_DIR", "/mock/override")
+
+		path, status := FileEnv("myapp", "config.yaml", "MYAPP_CONFIG_DIR")
+
+		if path != "/mock/override/config.yaml" {
+			t.Errorf("Expected path to be '/mock/override/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("env var empty", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG_DIR", "")
+
+		path, status := FileEnv("myapp", "config.yaml", "MYAPP_CONFIG_DIR")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestFileEnvPath(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	checkFile = func(path string) FileStatus {
+		if path == "/etc/myapp.conf" {
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("env var set", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG", "/etc/myapp.conf")
+
+		path, status := FileEnvPath("myapp", "config.yaml", "MYAPP_CONFIG")
+
+		if path != "/etc/myapp.conf" {
+			t.Errorf("Expected path to be '/etc/myapp.conf', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("env var relative", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG", "myapp.conf")
+		wd, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path, _ := FileEnvPath("myapp", "config.yaml", "MYAPP_CONFIG")
+
+		if expected := filepath.Join(wd, "myapp.conf"); path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+	})
+
+	t.Run("env var empty", func(t *testing.T) {
+		t.Setenv("MYAPP_CONFIG", "")
+
+		path, status := FileEnvPath("myapp", "config.yaml", "MYAPP_CONFIG")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
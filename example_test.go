@@ -1,7 +1,9 @@
 package dotconfig_test
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -31,3 +33,30 @@ func ExampleFile() {
 	}
 	fmt.Println(file)
 }
+
+func ExampleReadFile() {
+	data, path, err := dotconfig.ReadFile("myapp", "config.yaml")
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("no config found, using defaults")
+		return
+	} else if err != nil {
+		panic(err)
+	}
+	fmt.Println(path, len(data))
+}
+
+func ExampleFileStatus() {
+	var status dotconfig.FileStatus = dotconfig.BaseExists
+	switch status {
+	case dotconfig.FileExists:
+		fmt.Println("read existing config")
+	case dotconfig.BaseExists:
+		fmt.Println("create config in existing directory")
+	case dotconfig.NotExists:
+		fmt.Println("create config directory first")
+	}
+	fmt.Printf("status: %v\n", status)
+	// Output:
+	// create config in existing directory
+	// status: BaseExists
+}
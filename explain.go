@@ -0,0 +1,46 @@
+package dotconfig
+
+// Candidate is a location considered during a search along with its existence status.
+type Candidate struct {
+	// Path is the candidate path.
+	Path string
+
+	// Status reports whether the path exists, only its parent directory exists, or neither exists.
+	Status FileStatus
+}
+
+// Explain returns every candidate directory that [Dir] would consider for the specified application,
+// in search order, along with its existence status.
+//
+// Unlike [Dir], it does not stop at the first existing directory, which makes it useful
+// for reporting why a configuration was or was not found.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - The candidate directories and their statuses
+func Explain(app string) []Candidate {
+	var result []Candidate
+	for dir := range considered(app) {
+		result = append(result, Candidate{Path: dir, Status: checkDir(dir)})
+	}
+	return result
+}
+
+// ExplainFile returns every candidate file that [File] would consider for the specified application,
+// in search order, along with its existence status.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - The candidate files and their statuses
+func ExplainFile(app, name string) []Candidate {
+	var result []Candidate
+	for file := range ListFiles(app, name) {
+		result = append(result, Candidate{Path: file, Status: checkFile(file)})
+	}
+	return result
+}
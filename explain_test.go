@@ -0,0 +1,92 @@
+package dotconfig
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home" || dir == "/mock/home/.config" || dir == "/mock/home/.myapp"
+	}
+
+	t.Run("with home", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		expected := []Candidate{
+			{"/mock/home/.config/myapp", BaseExists},
+			{"/mock/home/lib/myapp", NotExists},
+			{"/mock/home/.myapp", FileExists},
+		}
+		if got := Explain("myapp"); !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+
+	t.Run("without home", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		expected := []Candidate{
+			{".myapp", NotExists},
+		}
+		if got := Explain("myapp"); !slices.Equal(got, expected) {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func TestExplainFile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	checkFile = func(path string) FileStatus {
+		switch path {
+		case "/mock/xdg/myapp/config.yaml":
+			return BaseExists
+		case "/mock/home/.myapp.yaml":
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	expected := []Candidate{
+		{"/mock/xdg/myapp/config.yaml", BaseExists},
+		{"/mock/home/lib/myapp/config.yaml", NotExists},
+		{"/mock/home/.myapp/config.yaml", NotExists},
+		{"/mock/home/.myapp.yaml", FileExists},
+	}
+	if got := ExplainFile("myapp", "config.yaml"); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -1,24 +1,40 @@
 package dotconfig
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"iter"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
-//go:generate stringer -type fileExists
+//go:generate stringer -type FileStatus
 
-type fileExists int
+// FileStatus reports whether a configuration file, or only its base directory, exists.
+type FileStatus int
 
 const (
 	// NotExists indicates that neither the file nor its base directory exists
-	NotExists fileExists = iota
+	NotExists FileStatus = iota
 
 	// BaseExists indicates that the file's base directory exists but the file itself does not
 	BaseExists
 
 	// FileExists indicates that the file exists
 	FileExists
+
+	// PermissionDenied indicates that the file or its base directory may exist
+	// but cannot be examined for lack of permission. It is only reported by [ProbeFile] and [ProbeDir].
+	PermissionDenied
+
+	// NotADirectory indicates that the file does not exist and cannot be created, because
+	// a path component that should be a directory, such as .<app>, is not a directory
+	NotADirectory
 )
 
 // File searches for a configuration file for the specified application.
@@ -28,13 +44,27 @@ const (
 //
 //  1. $XDG_CONFIG_HOME/<app>/<name> (if XDG_CONFIG_HOME is set)
 //  2. $HOME/.config/<app>/<name> (if XDG_CONFIG_HOME is not set)
-//  3. $HOME/lib/<app>/<name> (for Plan9 compatibility)
+//  3. $HOME/lib/<app>/<name> (on Plan9 only, see [WithPlan9Compat])
 //  4. $HOME/.<app>/<name> (if [os.UserHomeDir] returns no error)
 //  5. $HOME/.<app><ext> (where <ext> is the file extension of <name>, if [os.UserHomeDir] returns no error)
-//  6. .<app>/<name> (in current directory)
+//  6. .<app>/<name> (in current directory, if no other location could be determined)
 //  7. .<app><ext> (in current directory, as last resort)
 //
+// The extension <ext> is that of [filepath.Ext], extended by one more part when it is a known
+// compression suffix such as ".gz", so that "config.tar.gz" gives the fallback .<app>.tar.gz
+// while "app.config.json" gives .<app>.json; use [WithExtension] with [FileWith] to choose it explicitly.
+//
+// When running inside a Snap, that is, when SNAP and SNAP_USER_COMMON are set,
+// $SNAP_USER_COMMON/<app>/<name> is searched before all of these.
+//
+// If an existing file is found, it returns the file path and [FileExists].
+// If no existing file is found, it returns the first candidate location
+// along with its status ([BaseExists] or [NotExists]).
+// If app is not valid according to [ValidateApp], such as "..", nothing is searched and it returns "" and [NotExists].
+//
 // If the file name parameter is "." or "/", the application name is used as the file name.
+// For a namespaced application name such as "acme/tool", its last segment "tool" is used.
+// An empty file name is treated the same way; use [FileE] to reject it instead.
 //
 // Parameters:
 //   - app: The application name to search configurations for
@@ -42,81 +72,639 @@ const (
 //
 // Returns:
 //   - path: The configuration file path
-//   - status: A fileExists constant indicating whether the file exists, only its base directory exists, or neither exists
-func File(app, name string) (path string, status fileExists) {
-	cfg := newFileConfig(app, name)
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func File(app, name string) (path string, status FileStatus) {
+	result, _ := Locate(app, name)
+	return result.Path, result.Status
+}
+
+// FileContext is like [File] but checks ctx before each existence probe,
+// so that a search on a slow filesystem can be abandoned.
+//
+// If ctx is cancelled during the search, it returns ctx.Err().
+//
+// Parameters:
+//   - ctx: The context controlling cancellation of the search
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+//   - err: The context error if the search was cancelled
+func FileContext(ctx context.Context, app, name string) (path string, status FileStatus, err error) {
+	return newFileConfig(app, name).search(ctx, configLayout(), checkFile)
+}
+
+func searchFileContext(ctx context.Context, candidates iter.Seq[string]) (path string, status FileStatus, err error) {
+	return searchFileLocal(ctx, candidates, nil, checkFile)
+}
+
+// searchFileLocal returns the first existing file among candidates, probed with check.
+// If candidates is empty, the files of local are tried in order as the last resort,
+// and the last of them is returned if none exists.
+func searchFileLocal(ctx context.Context, candidates iter.Seq[string], local []string, check func(string) FileStatus) (path string, status FileStatus, err error) {
 	var fallback string
-	for file := range cfg.List() {
-		if check := checkFile(file); check == FileExists {
-			return file, check
+	for file := range candidates {
+		if err := ctx.Err(); err != nil {
+			return "", NotExists, err
+		}
+		if check := check(file); check == FileExists {
+			return file, check, nil
 		}
 		if fallback == "" {
 			fallback = file
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return "", NotExists, err
+	}
 	if fallback == "" {
-		file := filepath.Join("."+app, cfg.File)
-		if check := checkFile(file); check == FileExists {
+		if len(local) == 0 {
+			return "", NotExists, nil
+		}
+		for _, file := range local {
+			if check := check(file); check == FileExists {
+				return file, check, nil
+			}
+		}
+		fallback = local[len(local)-1]
+	}
+	return fallback, checkFile(fallback), nil
+}
+
+// FileWith is like [File] but accepts options that modify its behavior.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//   - opts: The options to apply
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileWith(app, name string, opts ...Option) (path string, status FileStatus) {
+	o := newOptions(opts)
+	cfg := newFileConfig(o.app(app), o.name(name))
+	if filepath.Ext(cfg.File) == "" {
+		cfg.File += o.defaultExt
+	}
+	cfg.Ext = o.ext
+	l := o.layout()
+	path, status, _ = cfg.search(context.Background(), l, o.checkFile())
+	if status == FileExists {
+		path = o.resolve(path)
+	} else if o.deepest {
+		if best := fewestMissing(cfg.dirFiles(l), filepath.Dir); best != "" {
+			path, status = best, checkFile(best)
+		}
+	}
+	return o.abs(path), status
+}
+
+// FileRequired is like [File] but only accepts an existing file. When no existing file is found,
+// it returns an error wrapping [fs.ErrNotExist] that lists every probed path, instead of a fallback path,
+// for callers that must fail loudly when the configuration is missing.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The existing configuration file path
+//   - err: An error wrapping [fs.ErrNotExist] if no existing file was found
+func FileRequired(app, name string) (path string, err error) {
+	var probed []string
+	for file := range ListFiles(app, name) {
+		if checkFile(file) == FileExists {
+			return file, nil
+		}
+		probed = append(probed, file)
+	}
+	return "", fmt.Errorf("dotconfig: %s not found in %s: %w", name, strings.Join(probed, ", "), fs.ErrNotExist)
+}
+
+// FilePreferExisting is like [File] but, when no existing file is found, prefers the first
+// candidate whose base directory already exists over the very first candidate.
+// This makes a new file land in an already-present configuration directory when possible.
+// Only the candidates inside a configuration directory, <dir>/<name>, are preferred this way;
+// the dot-prefixed file fallbacks are not, since their base directory is merely the home or current directory.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FilePreferExisting(app, name string) (path string, status FileStatus) {
+	var fallback, base string
+	var fallbackSource Source
+	for source, file := range newFileConfig(app, name).Sources(configLayout()) {
+		check := checkFile(file)
+		if check == FileExists {
 			return file, check
 		}
-		fallback = "." + app + filepath.Ext(cfg.File)
+		if fallback == "" || fallbackSource == SourceLocalDotDir {
+			// Among the current-directory fallbacks, the dot-file is preferred as in File.
+			fallback, fallbackSource = file, source
+		}
+		if base == "" && check == BaseExists && !source.isDotFile() {
+			base = file
+		}
+	}
+	if base != "" {
+		return base, BaseExists
 	}
 	return fallback, checkFile(fallback)
 }
 
-var checkFile = func(name string) fileExists {
+// FileIn is like [File] but first tries name in each of the directories given by the caller,
+// such as one supplied with a command-line flag, before the standard locations of [File].
+//
+// The first existing file wins. If no existing file is found, it returns name joined to the
+// first of dirs along with its status. If dirs is empty, it behaves exactly like [File].
+//
+// Parameters:
+//   - dirs: The directories to search before the standard locations
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileIn(dirs []string, app, name string) (path string, status FileStatus) {
+	if len(dirs) == 0 {
+		return File(app, name)
+	}
+	cfg := newFileConfig(app, name)
+	candidates := func(yield func(string) bool) {
+		for _, dir := range dirs {
+			if !yield(filepath.Join(dir, cfg.File)) {
+				return
+			}
+		}
+		for file := range cfg.List() {
+			if !yield(file) {
+				return
+			}
+		}
+	}
+	path, status, _ = searchFileContext(context.Background(), candidates)
+	return path, status
+}
+
+// FileAt is like [File] but the file is located at subpath under each directory location,
+// which allows layouts such as <app>/<version>/config.yaml. For example, FileAt("myapp", "v2", "config.yaml")
+// searches $XDG_CONFIG_HOME/myapp/v2/config.yaml, $HOME/.myapp/v2/config.yaml and so on.
+//
+// The dot-prefixed file fallbacks flatten the directory segments into the name with "-",
+// as in .myapp-v2.yaml, the same way as the profile of [FileProfile].
+// With a single segment it behaves like [File], and with no segment like [File] with the name ".".
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - subpath: The path segments of the configuration file under the configuration directory
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileAt(app string, subpath ...string) (path string, status FileStatus) {
+	name := "."
+	if n := len(subpath); n > 0 {
+		name = subpath[n-1]
+		subpath = subpath[:n-1]
+	}
+	cfg := newFileConfig(app, name)
+	cfg.Profile = filepath.Join(subpath...)
+	path, status, _ = cfg.search(context.Background(), configLayout(), checkFile)
+	return path, status
+}
+
+// FileExt searches for a configuration file for the specified application,
+// trying several file extensions for the same base name.
+//
+// For each location in the search order of [File], it tries base+ext for each
+// extension in the order given, and returns the first existing file.
+// If no existing file is found, it returns the first candidate location
+// using the first extension along with its status.
+// If no extensions are given, it behaves like [File] with base as the file name.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - base: The name of the configuration file without its extension
+//   - exts: The candidate file extensions including the leading dot, such as ".yaml"
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileExt(app, base string, exts ...string) (path string, status FileStatus) {
+	if len(exts) == 0 {
+		return File(app, base)
+	}
+	names := make([]string, len(exts))
+	for i, ext := range exts {
+		names[i] = base + ext
+	}
+	return searchNames(app, names)
+}
+
+// FileDefault is like [File] with the name "." but appends ext to the file name derived
+// from the application name, so that "myapp" with ".toml" searches for "myapp.toml"
+// instead of a file literally named "myapp".
+//
+// For a namespaced application name such as "acme/tool", its last segment is used, as in "tool.toml".
+// The dot-prefixed fallbacks become .<app><ext>, as with any other name.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - ext: The file extension including the leading dot, such as ".toml"
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileDefault(app, ext string) (path string, status FileStatus) {
+	cfg := newFileConfig(app, ".")
+	cfg.File += ext
+	path, status, _ = cfg.search(context.Background(), configLayout(), checkFile)
+	return path, status
+}
+
+// FileNamed is like [File] but computes the file name from the application name with transform,
+// so that a file such as "myapp.conf" is named without repeating the application name.
+// For example, FileNamed("myapp", func(app string) string { return app + ".conf" }) searches for "myapp.conf".
+//
+// transform receives the name that [File] uses for the "." sentinel, that is, the last segment
+// of a namespaced application name. A nil transform, like a transform returning "", uses that name as is.
+// The dot-prefixed fallbacks become .<app><ext> with the extension of the computed name, as with any other name.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - transform: The function computing the file name from the application name
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileNamed(app string, transform func(app string) string) (path string, status FileStatus) {
+	name := "."
+	if transform != nil {
+		name = transform(newFileConfig(app, ".").File)
+	}
+	return File(app, name)
+}
+
+// FileHost is like [File] but first looks for a variant of the file specific to this machine,
+// named with the short host name inserted before the extension, as in config.<hostname>.yaml.
+// The variant is searched in every location before the plain name is searched,
+// so that a host-specific override can be kept alongside a shared configuration.
+//
+// The short host name is the part of [os.Hostname] before the first dot.
+// If the variant exists nowhere, or the host name cannot be determined, it behaves like [File].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileHost(app, name string) (path string, status FileStatus) {
+	if host, err := hostname(); err == nil && host != "" {
+		host, _, _ = strings.Cut(host, ".")
+		file := newFileConfig(app, name).File
+		ext := compoundExt(file)
+		variant := strings.TrimSuffix(file, ext) + "." + host + ext
+		// The host name is part of the extension of the dot-file fallback too, as in .<app>.<hostname>.yaml.
+		if path, status := FileWith(app, variant, WithExtension("."+host+ext)); status == FileExists {
+			return path, status
+		}
+	}
+	return File(app, name)
+}
+
+var hostname = os.Hostname
+
+// searchNames tries each of names at each location in the search order of [File],
+// and returns the first existing file or the first candidate of the first name.
+// names must not be empty.
+func searchNames(app string, names []string) (path string, status FileStatus) {
+	l := configLayout().cacheHome()
+	lists := make([][]string, len(names))
+	for i, name := range names {
+		lists[i] = slices.Collect(newFileConfig(app, name).ListIn(l))
+	}
+	for i := range lists[0] {
+		for _, list := range lists {
+			if check := checkFile(list[i]); check == FileExists {
+				return list[i], check
+			}
+		}
+	}
+	fallback := lists[0][0]
+	if _, local := newFileConfig(app, names[0]).files(l); len(local) > 0 && fallback == local[0] {
+		// Only the current-directory fallbacks are known; prefer the dot-file as File does.
+		fallback = local[len(local)-1]
+	}
+	return fallback, checkFile(fallback)
+}
+
+// FileAll returns every existing configuration file for the specified application,
+// in the search order of [File], from the highest precedence to the lowest.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - The existing configuration file paths
+func FileAll(app, name string) []string {
+	return FileAllN(app, name, -1)
+}
+
+// FileAllN is like [FileAll] but stops searching once n existing files are found,
+// which bounds the existence checks for callers that only need the top few layers.
+//
+// As with [strings.SplitN], if n is negative all existing files are returned,
+// and if n is zero the result is nil and no file is checked.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//   - n: The maximum number of files to return
+//
+// Returns:
+//   - The existing configuration file paths, at most n of them
+func FileAllN(app, name string, n int) []string {
+	if n == 0 {
+		return nil
+	}
+	var files []string
+	for file := range ListFiles(app, name) {
+		if checkFile(file) == FileExists {
+			files = append(files, file)
+			if len(files) == n {
+				break
+			}
+		}
+	}
+	return files
+}
+
+// ListFiles returns an iterator over the candidate configuration files
+// for the specified application, in the same order that [File] searches them.
+//
+// Unlike [File], it does not check whether the files exist, which allows
+// callers to implement their own existence logic. The current-directory
+// fallbacks .<app>/<name> and .<app><ext> are included only when no other candidate could be determined.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - An iterator yielding candidate file paths
+func ListFiles(app, name string) iter.Seq[string] {
+	return newFileConfig(app, name).List()
+}
+
+// FileCandidates is like [ListFiles] but returns the candidate files as a slice.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - The candidate file paths in search order
+func FileCandidates(app, name string) []string {
+	return slices.Collect(ListFiles(app, name))
+}
+
+// CheckFile reports whether the file at name exists, only its base directory exists, or neither exists.
+// It uses the same existence semantics as [File].
+//
+// When the file does not exist and its base directory, or the nearest existing ancestor of it,
+// is not a directory, it reports [NotADirectory], since [os.MkdirAll] would fail to create the directory.
+func CheckFile(name string) FileStatus {
 	if _, err := os.Stat(name); err == nil { // if NO error
 		return FileExists
 	}
+	if blockedDir(filepath.Dir(name)) {
+		return NotADirectory
+	}
 	if _, err := os.Stat(filepath.Dir(name)); err == nil { // if NO error
 		return BaseExists
 	}
 	return NotExists
 }
 
+// blockedDir reports whether dir, or the nearest existing ancestor of it, exists but is not a directory.
+func blockedDir(dir string) bool {
+	for {
+		if info, err := os.Stat(dir); err == nil { // if NO error
+			return !info.IsDir()
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// checkFile is a test hook for [CheckFile]; only tests replace it, before any search starts.
+var checkFile = CheckFile
+
+// ProbeFile is like [CheckFile] but reports [PermissionDenied] instead of [BaseExists] or [NotExists]
+// when the file or its base directory cannot be examined for lack of permission,
+// so that a configuration that exists but is not readable is not mistaken for a missing one.
+//
+// It can be combined with [ListFiles] to report such candidates.
+func ProbeFile(name string) FileStatus {
+	_, err := os.Stat(name)
+	if err == nil { // if NO error
+		return FileExists
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return PermissionDenied
+	}
+	return probeBase(filepath.Dir(name))
+}
+
+// probeBase reports [BaseExists] if the directory dir exists, [NotADirectory] if it exists but is
+// not a directory, [PermissionDenied] if it cannot be examined for lack of permission, and [NotExists] otherwise.
+func probeBase(dir string) FileStatus {
+	info, err := os.Stat(dir)
+	if err == nil { // if NO error
+		if !info.IsDir() {
+			return NotADirectory
+		}
+		return BaseExists
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return PermissionDenied
+	}
+	return NotExists
+}
+
 type fileConfig struct {
-	App  string
-	File string
+	App     string
+	Profile string
+	File    string
+	Ext     string // the extension of the dot-file fallbacks; inferred from File if empty
 }
 
 func newFileConfig(app, file string) *fileConfig {
+	app = normalizeApp(app)
 	file = filepath.Base(file)
 	if file == "." || file == "/" {
-		file = app
+		file = path.Base(app)
 	}
 	return &fileConfig{App: app, File: file}
 }
 
 func (cfg *fileConfig) List() iter.Seq[string] {
+	return cfg.ListIn(configLayout())
+}
+
+func (cfg *fileConfig) ListIn(l layout) iter.Seq[string] {
 	return func(yield func(string) bool) {
-		if xdg := xdgConfigHome(); xdg != "" {
-			cfg.ListWithXDG(yield, xdg)
-		} else {
-			cfg.ListWithNoXDG(yield)
+		for _, path := range cfg.Sources(l) {
+			if !yield(path) {
+				return
+			}
 		}
 	}
 }
 
-func (cfg *fileConfig) ListWithXDG(yield func(string) bool, xdg string) {
-	if yield(filepath.Join(xdg, cfg.App, cfg.File)) {
-		if home, err := userHomeDir(); err == nil { // if NO error
-			cfg.ListHome(yield, home)
+// Sources yields the candidate files of l in search order, each with the [Source] that produced it,
+// followed by the current-directory fallbacks when no other candidate could be determined.
+func (cfg *fileConfig) Sources(l layout) iter.Seq2[Source, string] {
+	candidates, local := cfg.files(l)
+	return func(yield func(Source, string) bool) {
+		empty := true
+		for source, path := range candidates {
+			empty = false
+			if !yield(source, path) {
+				return
+			}
 		}
+		if empty && len(local) > 0 {
+			if yield(SourceLocalDotDir, local[0]) {
+				yield(SourceLocalDotFile, local[1])
+			}
+		}
+	}
+}
+
+// files returns the candidate files of l and the current-directory fallbacks.
+// As with [layout.dirs], a trailing LocalDot is not searched in order; its files,
+// .<app>/<name> and .<app><ext>, are the last resort when no other candidate could be determined.
+// local is empty if there is no such fallback, and holds those two files otherwise.
+func (cfg *fileConfig) files(l layout) (candidates iter.Seq2[Source, string], local []string) {
+	if l.hasFallback() && l.valid(cfg.App) {
+		dir := l.dotDir(cfg.App)
+		local = []string{filepath.Join(dir, cfg.Profile, cfg.File), cfg.dotFileNextTo(l, dir)}
 	}
+	l = l.searched()
+	return func(yield func(Source, string) bool) {
+		for loc, dir := range l.locations(cfg.App) {
+			dirSource, dotSource := locationSource(loc)
+			if !yield(dirSource, filepath.Join(dir, cfg.Profile, cfg.File)) {
+				return
+			}
+			if loc == HomeDot || loc == LocalDot {
+				if !yield(dotSource, cfg.dotFileNextTo(l, dir)) {
+					return
+				}
+			}
+		}
+	}, local
 }
 
-func (cfg *fileConfig) ListWithNoXDG(yield func(string) bool) {
-	if home, err := userHomeDir(); err == nil { // if NO error
-		if yield(filepath.Join(home, ".config", cfg.App, cfg.File)) {
-			cfg.ListHome(yield, home)
+// dirFiles yields the candidate files of l searched in order that lie inside a location directory,
+// <dir>/<name>, leaving out the dot-file fallbacks and the last-resort current directory.
+func (cfg *fileConfig) dirFiles(l layout) iter.Seq2[Location, string] {
+	return func(yield func(Location, string) bool) {
+		for loc, dir := range l.searched().locations(cfg.App) {
+			if !yield(loc, filepath.Join(dir, cfg.Profile, cfg.File)) {
+				return
+			}
 		}
 	}
 }
 
-func (cfg *fileConfig) ListHome(yield func(string) bool, home string) {
-	if yield(filepath.Join(home, "lib", cfg.App, cfg.File)) {
-		if yield(filepath.Join(home, "."+cfg.App, cfg.File)) {
-			yield(filepath.Join(home, "."+cfg.App+filepath.Ext(cfg.File)))
+// dotFileNextTo returns the dot-prefixed file fallback placed next to the dot-prefixed directory dir.
+func (cfg *fileConfig) dotFileNextTo(l layout, dir string) string {
+	dotFile := cfg.DotFile()
+	if l.ns != "" {
+		// Inside the namespace directory the file need not be hidden.
+		dotFile = strings.TrimPrefix(dotFile, ".")
+	}
+	return filepath.Join(filepath.Dir(dir), dotFile)
+}
+
+// search returns the first existing file of cfg in l, probed with check.
+// If no existing file is found, it returns the first candidate, or the dot-file .<app><ext>
+// when only the current-directory fallbacks could be determined.
+func (cfg *fileConfig) search(ctx context.Context, l layout, check func(string) FileStatus) (path string, status FileStatus, err error) {
+	candidates, local := cfg.files(l)
+	values := func(yield func(string) bool) {
+		for _, path := range candidates {
+			if !yield(path) {
+				return
+			}
 		}
 	}
+	return searchFileLocal(ctx, values, local, check)
 }
+
+// locationSource returns the [Source] of a file inside the directory of loc,
+// and of the dot-file fallback next to it for [HomeDot] and [LocalDot].
+func locationSource(loc Location) (dir, dotFile Source) {
+	switch loc {
+	case XDG:
+		return SourceXDG, SourceXDG
+	case DotConfig:
+		return SourceDotConfig, SourceDotConfig
+	case Plan9Lib:
+		return SourcePlan9Lib, SourcePlan9Lib
+	case HomeDot:
+		return SourceHomeDotDir, SourceHomeDotFile
+	case LocalDot:
+		return SourceLocalDotDir, SourceLocalDotFile
+	case Snap:
+		return SourceSnap, SourceSnap
+	case DotLocal:
+		return SourceDotLocal, SourceDotLocal
+	default:
+		return SourceEtc, SourceEtc
+	}
+}
+
+// DotFile returns the name of the dot-prefixed file fallback, .<app><ext>,
+// or .<app>-<profile><ext> when a profile is set, with any separator in the profile replaced by "-".
+func (cfg *fileConfig) DotFile() string {
+	name := dotName(cfg.App)
+	if cfg.Profile != "" {
+		name += "-" + strings.ReplaceAll(filepath.ToSlash(cfg.Profile), "/", "-")
+	}
+	ext := cfg.Ext
+	if ext == "" {
+		ext = compoundExt(cfg.File)
+	}
+	return name + ext
+}
+
+// compoundExt returns the extension of name as [filepath.Ext] does, but when it is one of
+// compressionExts, the extension before it is included too, so that "config.tar.gz" yields
+// ".tar.gz" rather than ".gz". Other dots are part of the name: "config-1.2.yaml" yields ".yaml".
+func compoundExt(name string) string {
+	ext := filepath.Ext(name)
+	if !slices.Contains(compressionExts, ext) {
+		return ext
+	}
+	return filepath.Ext(strings.TrimSuffix(name, ext)) + ext
+}
+
+// compressionExts are the suffixes that wrap another extension, as in .tar.gz.
+var compressionExts = []string{".gz", ".bz2", ".xz", ".zst", ".lz4", ".br"}
@@ -1,7 +1,15 @@
 package dotconfig
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -21,7 +29,7 @@ func TestFile(t *testing.T) {
 	t.Run("XDG config file exists", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "/mock/xdg" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/xdg/myapp/config.yaml" {
 				return FileExists
 			}
@@ -44,7 +52,7 @@ func TestFile(t *testing.T) {
 	t.Run("XDG config base exists, file not exists", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "/mock/xdg" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/xdg/myapp/config.yaml" {
 				return BaseExists
 			}
@@ -67,7 +75,7 @@ func TestFile(t *testing.T) {
 	t.Run("XDG not exist, home .config file exists", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/home/.config/myapp/config.yaml" {
 				return FileExists
 			}
@@ -90,7 +98,7 @@ func TestFile(t *testing.T) {
 	t.Run("XDG not exist, home lib file exists", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/home/lib/myapp/config.yaml" {
 				return FileExists
 			}
@@ -113,7 +121,7 @@ func TestFile(t *testing.T) {
 	t.Run("XDG not exist, dot file exists", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/home/.myapp.yaml" {
 				return FileExists
 			}
@@ -136,7 +144,7 @@ func TestFile(t *testing.T) {
 	t.Run("No files exist, fallback to first option", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "/mock/xdg" }
-		checkFile = func(path string) fileExists { return NotExists }
+		checkFile = func(path string) FileStatus { return NotExists }
 		userHomeDir = func() (string, error) {
 			return "/mock/home", nil
 		}
@@ -151,10 +159,33 @@ func TestFile(t *testing.T) {
 		}
 	})
 
+	t.Run("Home available, local dot directory file ignored", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		checkFile = func(path string) FileStatus {
+			if path == ".myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		path, status := File("myapp", "config.yaml")
+
+		if path != "/mock/home/.config/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.config/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
 	t.Run("No locations available, use local dot directory file", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == ".myapp/config.yaml" {
 				return FileExists
 			}
@@ -177,7 +208,7 @@ func TestFile(t *testing.T) {
 	t.Run("No locations available, use local dot file", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == ".myapp.yaml" {
 				return FileExists
 			}
@@ -200,7 +231,7 @@ func TestFile(t *testing.T) {
 	t.Run("No locations available, local file not exist", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "" }
-		checkFile = func(path string) fileExists { return NotExists }
+		checkFile = func(path string) FileStatus { return NotExists }
 		userHomeDir = func() (string, error) {
 			return "", os.ErrNotExist
 		}
@@ -218,7 +249,7 @@ func TestFile(t *testing.T) {
 	t.Run("Use app name when file name is '.'", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "/mock/xdg" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/xdg/myapp/myapp" {
 				return FileExists
 			}
@@ -241,7 +272,7 @@ func TestFile(t *testing.T) {
 	t.Run("Use app name when file name is '/'", func(t *testing.T) {
 		// Mock functions
 		xdgConfigHome = func() string { return "/mock/xdg" }
-		checkFile = func(path string) fileExists {
+		checkFile = func(path string) FileStatus {
 			if path == "/mock/xdg/myapp/myapp" {
 				return FileExists
 			}
@@ -367,6 +398,14 @@ func TestNewFileConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("file name is empty", func(t *testing.T) {
+		cfg := newFileConfig("myapp", "")
+
+		if cfg.File != "myapp" {
+			t.Errorf("Expected File to be 'myapp', got '%s'", cfg.File)
+		}
+	})
+
 	t.Run("file name is slash", func(t *testing.T) {
 		cfg := newFileConfig("myapp", "/")
 
@@ -392,10 +431,56 @@ func TestNewFileConfig(t *testing.T) {
 	})
 }
 
+func TestNewFileConfigNormalizeApp(t *testing.T) {
+	t.Run("app with trailing slash", func(t *testing.T) {
+		cfg := newFileConfig("myapp/", "config.yaml")
+
+		if cfg.App != "myapp" {
+			t.Errorf("Expected App to be 'myapp', got '%s'", cfg.App)
+		}
+
+		if cfg.DotFile() != ".myapp.yaml" {
+			t.Errorf("Expected DotFile to be '.myapp.yaml', got '%s'", cfg.DotFile())
+		}
+	})
+
+	t.Run("app with leading slash", func(t *testing.T) {
+		cfg := newFileConfig("/myapp", "config.yaml")
+
+		if cfg.App != "myapp" {
+			t.Errorf("Expected App to be 'myapp', got '%s'", cfg.App)
+		}
+	})
+
+	t.Run("app with trailing slash and file name is dot", func(t *testing.T) {
+		cfg := newFileConfig("myapp/", ".")
+
+		if cfg.App != "myapp" {
+			t.Errorf("Expected App to be 'myapp', got '%s'", cfg.App)
+		}
+
+		if cfg.File != "myapp" {
+			t.Errorf("Expected File to be 'myapp', got '%s'", cfg.File)
+		}
+	})
+
+	t.Run("app with trailing slash and file name is slash", func(t *testing.T) {
+		cfg := newFileConfig("myapp//", "/")
+
+		if cfg.App != "myapp" {
+			t.Errorf("Expected App to be 'myapp', got '%s'", cfg.App)
+		}
+
+		if cfg.File != "myapp" {
+			t.Errorf("Expected File to be 'myapp', got '%s'", cfg.File)
+		}
+	})
+}
+
 func TestCheckFile(t *testing.T) {
 	testCases := []struct {
 		File     string
-		Expected fileExists
+		Expected FileStatus
 	}{
 		{"file_test.go", FileExists},
 		{"not_exists", BaseExists},
@@ -408,3 +493,1212 @@ func TestCheckFile(t *testing.T) {
 		}
 	}
 }
+
+func TestListFiles(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("with home", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		paths := []string{}
+		for path := range ListFiles("myapp", "config.yaml") {
+			paths = append(paths, path)
+		}
+
+		expectedPaths := []string{
+			"/mock/home/.config/myapp/config.yaml",
+			"/mock/home/lib/myapp/config.yaml",
+			"/mock/home/.myapp/config.yaml",
+			"/mock/home/.myapp.yaml",
+		}
+
+		if len(paths) != len(expectedPaths) {
+			t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+		}
+
+		for i, expected := range expectedPaths {
+			if i >= len(paths) {
+				t.Errorf("Missing expected path at index %d: %s", i, expected)
+				continue
+			}
+			if paths[i] != expected {
+				t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+			}
+		}
+	})
+
+	t.Run("without home", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		paths := []string{}
+		for path := range ListFiles("myapp", "config.yaml") {
+			paths = append(paths, path)
+		}
+
+		expectedPaths := []string{
+			".myapp/config.yaml",
+			".myapp.yaml",
+		}
+
+		if len(paths) != len(expectedPaths) {
+			t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+		}
+
+		for i, expected := range expectedPaths {
+			if i >= len(paths) {
+				t.Errorf("Missing expected path at index %d: %s", i, expected)
+				continue
+			}
+			if paths[i] != expected {
+				t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+			}
+		}
+	})
+
+	t.Run("early termination", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		called := 0
+		for range ListFiles("myapp", "config.yaml") {
+			called++
+			break
+		}
+
+		if called != 1 {
+			t.Errorf("Expected yield to be called 1 time, got %d", called)
+		}
+	})
+}
+
+func TestFileExt(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("second extension exists in first location", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/myapp/config.yml" || path == "/mock/home/.myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		path, status := FileExt("myapp", "config", ".yaml", ".yml")
+
+		if path != "/mock/xdg/myapp/config.yml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("dot file with second extension exists", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.json" {
+				return FileExists
+			}
+			return NotExists
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		path, status := FileExt("myapp", "config", ".yaml", ".json")
+
+		if path != "/mock/home/.myapp.json" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.json', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("No files exist, fallback to first option with first extension", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		checkFile = func(path string) FileStatus { return NotExists }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		path, status := FileExt("myapp", "config", ".toml", ".json")
+
+		if path != "/mock/xdg/myapp/config.toml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.toml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("No extensions behaves like File", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		checkFile = func(path string) FileStatus { return NotExists }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		path, status := FileExt("myapp", "config")
+
+		if path != "/mock/xdg/myapp/config" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestFileConfig_ListNamespaced(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("file name", func(t *testing.T) {
+		cfg := newFileConfig("acme/tool", "config.yaml")
+		paths := []string{}
+		for path := range cfg.List() {
+			paths = append(paths, path)
+		}
+
+		expectedPaths := []string{
+			"/mock/xdg/acme/tool/config.yaml",
+			"/mock/home/lib/acme/tool/config.yaml",
+			"/mock/home/.acme-tool/config.yaml",
+			"/mock/home/.acme-tool.yaml",
+		}
+
+		if len(paths) != len(expectedPaths) {
+			t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+		}
+
+		for i, expected := range expectedPaths {
+			if i >= len(paths) {
+				t.Errorf("Missing expected path at index %d: %s", i, expected)
+				continue
+			}
+			if paths[i] != expected {
+				t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+			}
+		}
+	})
+
+	t.Run("file name is dot", func(t *testing.T) {
+		cfg := newFileConfig("acme/tool", ".")
+
+		if cfg.File != "tool" {
+			t.Errorf("Expected File to be 'tool', got '%s'", cfg.File)
+		}
+	})
+}
+
+func TestFileWith(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	xdg := filepath.Join(tmp, "xdg")
+	if err := os.MkdirAll(filepath.Join(xdg, "myapp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "real.yaml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tmp, "real.yaml"), filepath.Join(xdg, "myapp", "config.yaml")); err != nil {
+		t.Skip(err)
+	}
+
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("without options", func(t *testing.T) {
+		path, status := FileWith("myapp", "config.yaml")
+
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("resolve symlinks", func(t *testing.T) {
+		path, status := FileWith("myapp", "config.yaml", WithResolveSymlinks())
+
+		expected := filepath.Join(tmp, "real.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("resolve symlinks of missing file", func(t *testing.T) {
+		path, status := FileWith("myapp", "other.yaml", WithResolveSymlinks())
+
+		expected := filepath.Join(xdg, "myapp", "other.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+}
+
+func TestFileContext(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("not cancelled", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status, err := FileContext(context.Background(), "myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "/mock/home/.myapp.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("cancelled mid-search", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		probed := 0
+		checkFile = func(path string) FileStatus {
+			probed++
+			if probed == 2 {
+				cancel()
+			}
+			return NotExists
+		}
+
+		path, status, err := FileContext(ctx, "myapp", "config.yaml")
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected path to be empty, got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+		if probed != 2 {
+			t.Errorf("Expected checkFile to be called 2 times, got %d", probed)
+		}
+	})
+}
+
+func TestCheckFileExported(t *testing.T) {
+	testCases := []struct {
+		File     string
+		Expected FileStatus
+	}{
+		{"file_test.go", FileExists},
+		{"not_exists", BaseExists},
+		{"not_exists/not_exists", NotExists},
+		{"file_test.go/config.yaml", NotADirectory},
+		{"file_test.go/sub/config.yaml", NotADirectory},
+	}
+
+	for _, tc := range testCases {
+		if got := CheckFile(tc.File); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestFileStatus_String(t *testing.T) {
+	testCases := []struct {
+		Status   FileStatus
+		Expected string
+	}{
+		{NotExists, "NotExists"},
+		{BaseExists, "BaseExists"},
+		{FileExists, "FileExists"},
+		{PermissionDenied, "PermissionDenied"},
+		{NotADirectory, "NotADirectory"},
+		{FileStatus(-1), "FileStatus(-1)"},
+		{FileStatus(42), "FileStatus(42)"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.Status.String(); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+		if got := fmt.Sprintf("%v", tc.Status); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestFileWithExpandEnv(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Setenv("DOTCONFIG_TEST_PROFILE", "work")
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	checkFile = func(path string) FileStatus { return NotExists }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	path, _ := FileWith("myapp-$DOTCONFIG_TEST_PROFILE", "$DOTCONFIG_TEST_PROFILE.yaml", WithExpandEnv())
+
+	if path != "/mock/xdg/myapp-work/work.yaml" {
+		t.Errorf("Expected path to be '/mock/xdg/myapp-work/work.yaml', got '%s'", path)
+	}
+}
+
+func TestFilePreferExisting(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("file exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			switch path {
+			case "/mock/home/.myapp/config.yaml":
+				return BaseExists
+			case "/mock/home/.myapp.yaml":
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FilePreferExisting("myapp", "config.yaml")
+
+		if path != "/mock/home/.myapp.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("base dir exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp/config.yaml" {
+				return BaseExists
+			}
+			return NotExists
+		}
+
+		path, status := FilePreferExisting("myapp", "config.yaml")
+
+		if path != "/mock/home/.myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp/config.yaml', got '%s'", path)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+
+	t.Run("only home dot-file base exists", func(t *testing.T) {
+		// $HOME exists, but it is not a configuration directory.
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.yaml" {
+				return BaseExists
+			}
+			return NotExists
+		}
+
+		path, status := FilePreferExisting("myapp", "config.yaml")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("nothing exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus { return NotExists }
+
+		path, status := FilePreferExisting("myapp", "config.yaml")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestFileIn(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("supplied directory wins", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/flag/two/config.yaml" || path == "/mock/xdg/myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileIn([]string{"/flag/one", "/flag/two"}, "myapp", "config.yaml")
+
+		if path != "/flag/two/config.yaml" {
+			t.Errorf("Expected path to be '/flag/two/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("standard location after supplied directories", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileIn([]string{"/flag/one"}, "myapp", "config.yaml")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("fallback to first supplied directory", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/flag/one/config.yaml" {
+				return BaseExists
+			}
+			return NotExists
+		}
+
+		path, status := FileIn([]string{"/flag/one", "/flag/two"}, "myapp", "config.yaml")
+
+		if path != "/flag/one/config.yaml" {
+			t.Errorf("Expected path to be '/flag/one/config.yaml', got '%s'", path)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+
+	t.Run("no supplied directories", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		path, status := FileIn(nil, "myapp", "config.yaml")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestFileDefault(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("recommended path", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		path, status := FileDefault("myapp", ".toml")
+
+		if path != "/mock/xdg/myapp/myapp.toml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/myapp.toml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("home dot file exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.toml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileDefault("myapp", ".toml")
+
+		if path != "/mock/home/.myapp.toml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.toml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("namespaced app", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		path, _ := FileDefault("acme/tool", ".toml")
+
+		if path != "/mock/xdg/acme/tool/tool.toml" {
+			t.Errorf("Expected path to be '/mock/xdg/acme/tool/tool.toml', got '%s'", path)
+		}
+	})
+}
+
+func TestFileAll(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	checkFile = func(path string) FileStatus {
+		switch path {
+		case "/mock/xdg/myapp/config.yaml", "/mock/home/.myapp.yaml", ".myapp/config.yaml":
+			return FileExists
+		}
+		return BaseExists
+	}
+
+	files := FileAll("myapp", "config.yaml")
+
+	expected := []string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp.yaml"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+
+	checkFile = func(path string) FileStatus {
+		return NotExists
+	}
+	if files := FileAll("myapp", "config.yaml"); len(files) != 0 {
+		t.Errorf("Expected no files, got %v", files)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	tmp := t.TempDir()
+	file := filepath.Join(tmp, "config.yaml")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Probe    func(string) FileStatus
+		Path     string
+		Expected FileStatus
+	}{
+		{"file exists", ProbeFile, file, FileExists},
+		{"file base exists", ProbeFile, filepath.Join(tmp, "missing.yaml"), BaseExists},
+		{"file not exists", ProbeFile, filepath.Join(tmp, "missing", "config.yaml"), NotExists},
+		{"dir exists", ProbeDir, tmp, FileExists},
+		{"dir parent exists", ProbeDir, filepath.Join(tmp, "missing"), BaseExists},
+		{"dir not exists", ProbeDir, filepath.Join(tmp, "missing", "myapp"), NotExists},
+		{"dir is a file", ProbeDir, file, BaseExists},
+	}
+	for _, tc := range testCases {
+		if got := tc.Probe(tc.Path); got != tc.Expected {
+			t.Errorf("%s: Expected %v, got %v", tc.Name, tc.Expected, got)
+		}
+	}
+
+	t.Run("permission denied", func(t *testing.T) {
+		if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+			t.Skip("permission bits are not enforced")
+		}
+		locked := filepath.Join(tmp, "locked")
+		if err := os.MkdirAll(filepath.Join(locked, "myapp"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(locked, 0); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chmod(locked, 0700)
+
+		if got := ProbeFile(filepath.Join(locked, "myapp", "config.yaml")); got != PermissionDenied {
+			t.Errorf("Expected ProbeFile to report PermissionDenied, got %v", got)
+		}
+		if got := ProbeDir(filepath.Join(locked, "myapp")); got != PermissionDenied {
+			t.Errorf("Expected ProbeDir to report PermissionDenied, got %v", got)
+		}
+		if got := CheckFile(filepath.Join(locked, "myapp", "config.yaml")); got != NotExists {
+			t.Errorf("Expected CheckFile to be unchanged and report NotExists, got %v", got)
+		}
+	})
+}
+
+func TestCompoundExt(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{"config.yaml", ".yaml"},
+		{"config.tar.gz", ".tar.gz"},
+		{"config.json.zst", ".json.zst"},
+		{"config.gz", ".gz"},
+		{"config-1.2.yaml", ".yaml"},
+		{"app.config.json", ".json"},
+		{"config", ""},
+		{".env", ".env"},
+		{".env.local", ".local"},
+		{"myapp", ""},
+	}
+	for _, tc := range testCases {
+		if got := compoundExt(tc.Name); got != tc.Expected {
+			t.Errorf("Expected %q for %q, got %q", tc.Expected, tc.Name, got)
+		}
+	}
+}
+
+func TestFileCompoundExtension(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	checkFile = func(path string) FileStatus {
+		switch path {
+		case "/mock/home/.myapp.tar.gz", "/mock/home/.myapp.conf":
+			return FileExists
+		}
+		return NotExists
+	}
+
+	path, status := File("myapp", "config.tar.gz")
+	if path != "/mock/home/.myapp.tar.gz" {
+		t.Errorf("Expected path to be '/mock/home/.myapp.tar.gz', got '%s'", path)
+	}
+	if status != FileExists {
+		t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+	}
+
+	path, _ = FileWith("myapp", "config.tar.gz", WithExtension(".conf"))
+	if path != "/mock/home/.myapp.conf" {
+		t.Errorf("Expected path to be '/mock/home/.myapp.conf', got '%s'", path)
+	}
+
+	// A dot before the real extension belongs to the name.
+	checkFile = func(path string) FileStatus {
+		if path == "/mock/home/.myapp.json" {
+			return FileExists
+		}
+		return NotExists
+	}
+	path, _ = File("myapp", "app.config.json")
+	if path != "/mock/home/.myapp.json" {
+		t.Errorf("Expected path to be '/mock/home/.myapp.json', got '%s'", path)
+	}
+	path, _ = File("myapp", "config-1.2.json")
+	if path != "/mock/home/.myapp.json" {
+		t.Errorf("Expected path to be '/mock/home/.myapp.json', got '%s'", path)
+	}
+}
+
+func TestFileAt(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("versioned file", func(t *testing.T) {
+		probed := []string{}
+		checkFile = func(path string) FileStatus {
+			probed = append(probed, path)
+			return NotExists
+		}
+
+		path, status := FileAt("myapp", "v2", "config.yaml")
+
+		if path != "/mock/xdg/myapp/v2/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/v2/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+		expected := []string{
+			"/mock/xdg/myapp/v2/config.yaml",
+			"/mock/home/lib/myapp/v2/config.yaml",
+			"/mock/home/.myapp/v2/config.yaml",
+			"/mock/home/.myapp-v2.yaml",
+		}
+		if !slices.Equal(probed[:len(expected)], expected) {
+			t.Errorf("Expected %v, got %v", expected, probed)
+		}
+	})
+
+	t.Run("nested subpath flattened", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp-v2-beta.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileAt("myapp", "v2", "beta", "config.yaml")
+
+		if path != "/mock/home/.myapp-v2-beta.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp-v2-beta.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("single segment and none", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		if path, _ := FileAt("myapp", "config.yaml"); path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if path, _ := FileAt("myapp"); path != "/mock/xdg/myapp/myapp" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/myapp', got '%s'", path)
+		}
+	})
+}
+
+func TestFileAllN(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	probed := 0
+	checkFile = func(path string) FileStatus {
+		probed++
+		switch path {
+		case "/mock/xdg/myapp/config.yaml", "/mock/home/.myapp/config.yaml", ".myapp/config.yaml":
+			return FileExists
+		}
+		return NotExists
+	}
+
+	testCases := []struct {
+		N        int
+		Expected []string
+		Probed   int
+	}{
+		{0, nil, 0},
+		{1, []string{"/mock/xdg/myapp/config.yaml"}, 1},
+		{2, []string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp/config.yaml"}, 3},
+		{-1, []string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp/config.yaml"}, 4},
+		{10, []string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp/config.yaml"}, 4},
+	}
+	for _, tc := range testCases {
+		probed = 0
+		files := FileAllN("myapp", "config.yaml", tc.N)
+		if !slices.Equal(files, tc.Expected) {
+			t.Errorf("Expected %v for n=%d, got %v", tc.Expected, tc.N, files)
+		}
+		if probed != tc.Probed {
+			t.Errorf("Expected %d checks for n=%d, got %d", tc.Probed, tc.N, probed)
+		}
+	}
+}
+
+func BenchmarkFileAllN(b *testing.B) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := b.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return b.TempDir(), nil
+	}
+	if _, err := WriteFile("myapp", "config.yaml", nil); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("all", func(b *testing.B) {
+		for range b.N {
+			FileAll("myapp", "config.yaml")
+		}
+	})
+	b.Run("first", func(b *testing.B) {
+		for range b.N {
+			FileAllN("myapp", "config.yaml", 1)
+		}
+	})
+}
+
+func TestFileRequired(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.yaml" {
+				return FileExists
+			}
+			return BaseExists
+		}
+
+		path, err := FileRequired("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "/mock/home/.myapp.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.yaml', got '%s'", path)
+		}
+	})
+
+	t.Run("no existing file", func(t *testing.T) {
+		checkFile = func(path string) FileStatus { return BaseExists }
+
+		path, err := FileRequired("myapp", "config.yaml")
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("Expected fs.ErrNotExist, got %v", err)
+		}
+		if path != "" {
+			t.Errorf("Expected empty path, got '%s'", path)
+		}
+		for file := range ListFiles("myapp", "config.yaml") {
+			if !strings.Contains(err.Error(), file) {
+				t.Errorf("Expected error to list '%s', got %v", file, err)
+			}
+		}
+	})
+}
+
+func TestFileNotADirectory(t *testing.T) {
+	tmp := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmp, ".myapp"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		File     string
+		Expected FileStatus
+	}{
+		{filepath.Join(tmp, ".myapp", "config.yaml"), NotADirectory},
+		{filepath.Join(tmp, ".myapp.yaml"), BaseExists},
+	}
+
+	for _, tc := range testCases {
+		if got := CheckFile(tc.File); got != tc.Expected {
+			t.Errorf("Expected %v for %s, got %v", tc.Expected, tc.File, got)
+		}
+		if got := ProbeFile(tc.File); got != tc.Expected {
+			t.Errorf("Expected %v for %s, got %v", tc.Expected, tc.File, got)
+		}
+	}
+}
+
+func TestFileNamed(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	checkFile = func(path string) FileStatus {
+		if path == "/mock/home/.tool.conf" {
+			return FileExists
+		}
+		return NotExists
+	}
+	conf := func(app string) string { return app + ".conf" }
+
+	testCases := []struct {
+		App       string
+		Transform func(string) string
+		Expected  string
+	}{
+		{"myapp", conf, "/mock/xdg/myapp/myapp.conf"},
+		{"acme/tool", conf, "/mock/xdg/acme/tool/tool.conf"},
+		{"tool", conf, "/mock/home/.tool.conf"},
+		{"myapp", nil, "/mock/xdg/myapp/myapp"},
+		{"myapp", func(string) string { return "" }, "/mock/xdg/myapp/myapp"},
+	}
+
+	for _, tc := range testCases {
+		if path, _ := FileNamed(tc.App, tc.Transform); path != tc.Expected {
+			t.Errorf("Expected path to be '%s', got '%s'", tc.Expected, path)
+		}
+	}
+}
+
+func TestFileHost(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+	origHostname := hostname
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+		hostname = origHostname
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	hostname = func() (string, error) {
+		return "box.example.com", nil
+	}
+
+	testCases := []struct {
+		Exists   []string
+		Expected string
+	}{
+		{[]string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp/config.box.yaml"}, "/mock/home/.myapp/config.box.yaml"},
+		{[]string{"/mock/xdg/myapp/config.yaml", "/mock/home/.myapp.box.yaml"}, "/mock/home/.myapp.box.yaml"},
+		{[]string{"/mock/home/.myapp/config.yaml"}, "/mock/home/.myapp/config.yaml"},
+		{nil, "/mock/xdg/myapp/config.yaml"},
+	}
+	for _, tc := range testCases {
+		checkFile = func(path string) FileStatus {
+			if slices.Contains(tc.Exists, path) {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		if path, _ := FileHost("myapp", "config.yaml"); path != tc.Expected {
+			t.Errorf("Expected path to be '%s', got '%s'", tc.Expected, path)
+		}
+	}
+
+	t.Run("hostname unavailable", func(t *testing.T) {
+		hostname = func() (string, error) {
+			return "", errors.New("mock error")
+		}
+		checkFile = func(path string) FileStatus { return NotExists }
+
+		if path, _ := FileHost("myapp", "config.yaml"); path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+	})
+}
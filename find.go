@@ -0,0 +1,53 @@
+package dotconfig
+
+// Find searches for either the configuration directory or a configuration file of the
+// specified application, always reporting the three-state status of [File].
+//
+// The variadic names select the mode:
+//
+//   - With no name, it behaves like [DirStatus] and locates the configuration directory.
+//   - With one name, it behaves like [File] and locates that file.
+//   - With several names, they are alternatives tried in the given order at each location,
+//     as in [FileExt], and the first existing file wins. If none exists, the path
+//     recommended for the first name is returned.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The names of the configuration file to find, or none to find the directory
+//
+// Returns:
+//   - path: The configuration directory or file path
+//   - status: A FileStatus value indicating whether the path exists, only its base directory exists, or neither exists
+func Find(app string, name ...string) (path string, status FileStatus) {
+	switch len(name) {
+	case 0:
+		return DirStatus(app)
+	case 1:
+		return File(app, name[0])
+	default:
+		return searchNames(app, name)
+	}
+}
+
+// FindFunc returns the first candidate configuration directory of the specified application,
+// in the same order that [Dir] considers them, for which pred returns true.
+// It generalizes the existence check of [Dir], for example to find the first directory
+// containing a marker file, or the first writable one.
+//
+// The search stops as soon as pred returns true, so pred is called no more often than necessary.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - pred: The predicate each candidate directory is tested with
+//
+// Returns:
+//   - dir: The first directory satisfying pred, or "" if none does
+//   - ok: Boolean indicating whether any directory satisfied pred
+func FindFunc(app string, pred func(path string) bool) (dir string, ok bool) {
+	for dir := range considered(app) {
+		if pred(dir) {
+			return dir, true
+		}
+	}
+	return "", false
+}
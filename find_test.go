@@ -0,0 +1,150 @@
+package dotconfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.myapp"
+		}
+
+		path, status := Find("myapp")
+
+		if path != "/mock/home/.myapp" {
+			t.Errorf("Expected path to be '/mock/home/.myapp', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("directory not exists", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg"
+		}
+
+		path, status := Find("myapp")
+
+		if path != "/mock/xdg/myapp" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp', got '%s'", path)
+		}
+		if status != BaseExists {
+			t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := Find("myapp", "config.yaml")
+
+		if path != "/mock/home/.myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("alternative files", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/myapp/config.json" || path == "/mock/home/.myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := Find("myapp", "config.yaml", "config.json")
+
+		if path != "/mock/xdg/myapp/config.json" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.json', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("alternative files not exist", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		path, status := Find("myapp", "config.yaml", "config.json")
+
+		if path != "/mock/xdg/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
+
+func TestFindFunc(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("match", func(t *testing.T) {
+		var probed []string
+		dir, ok := FindFunc("myapp", func(path string) bool {
+			probed = append(probed, path)
+			return path == "/mock/home/.myapp"
+		})
+
+		if dir != "/mock/home/.myapp" || !ok {
+			t.Errorf("Expected '/mock/home/.myapp', true, got '%s', %v", dir, ok)
+		}
+		if expected := []string{"/mock/xdg/myapp", "/mock/home/lib/myapp", "/mock/home/.myapp"}; !slices.Equal(probed, expected) {
+			t.Errorf("Expected %q to be probed, got %q", expected, probed)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		dir, ok := FindFunc("myapp", func(path string) bool { return false })
+
+		if dir != "" || ok {
+			t.Errorf("Expected '', false, got '%s', %v", dir, ok)
+		}
+	})
+}
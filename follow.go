@@ -0,0 +1,69 @@
+package dotconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirFollow is like [Dir] but follows a redirect: when $HOME/.<app> is a regular file
+// rather than a directory, its content, a single path, is used as the configuration directory.
+// This supports dotfiles managers that leave a pointer to the real directory in the home directory.
+//
+// The redirect is considered at the position of $HOME/.<app> in the search order, so an existing
+// directory searched earlier still wins. A relative path in the file is resolved against the
+// home directory. The target is used as is, even if it is itself a file, so at most one level
+// is followed and a redirect loop is impossible. A file that is empty or holds more than
+// one line is not a redirect and is ignored.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirFollow(app string) (dir string, exist bool) {
+	l := configLayout().cacheHome()
+	var pointer string
+	if home, err := l.homeDir(); err == nil { // if NO error
+		pointer = filepath.Join(home, l.dotDir(app))
+	}
+	candidates, local := l.dirs(app)
+	var target string
+	exists := func(dir string) bool {
+		if dirExists(dir) {
+			return true
+		}
+		if dir == pointer {
+			target = readRedirect(dir)
+		}
+		return target != ""
+	}
+	dir, status, _ := searchDirProbe(context.Background(), candidates, local, exists)
+	if target != "" {
+		return target, dirExists(target)
+	}
+	return dir, status == FileExists
+}
+
+// readRedirect returns the path held by the regular file at name, resolved against its directory,
+// or "" if name is not a regular file holding a single path.
+func readRedirect(name string) string {
+	info, err := os.Stat(name)
+	if err != nil || !info.Mode().IsRegular() {
+		return ""
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ""
+	}
+	path := strings.TrimSpace(string(data))
+	if path == "" || strings.ContainsAny(path, "\r\n") {
+		return ""
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(name), path)
+	}
+	return path
+}
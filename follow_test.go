@@ -0,0 +1,72 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirFollow(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	home := t.TempDir()
+	real := filepath.Join(home, "dotfiles", "myapp")
+	if err := os.MkdirAll(real, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	testCases := []struct {
+		Pointer  string
+		Expected string
+		Exist    bool
+	}{
+		{real + "\n", real, true},
+		{filepath.Join("dotfiles", "myapp"), real, true},
+		{filepath.Join(home, "missing"), filepath.Join(home, "missing"), false},
+		{filepath.Join(home, ".myapp"), filepath.Join(home, ".myapp"), false},
+		{"", filepath.Join(home, ".config", "myapp"), false},
+		{real + "\n" + real, filepath.Join(home, ".config", "myapp"), false},
+	}
+
+	for _, tc := range testCases {
+		if err := os.WriteFile(filepath.Join(home, ".myapp"), []byte(tc.Pointer), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		dir, exist := DirFollow("myapp")
+
+		if dir != tc.Expected || exist != tc.Exist {
+			t.Errorf("Expected '%s', %v for %q, got '%s', %v", tc.Expected, tc.Exist, tc.Pointer, dir, exist)
+		}
+	}
+
+	t.Run("earlier directory wins", func(t *testing.T) {
+		dotConfig := filepath.Join(home, ".config", "myapp")
+		if err := os.MkdirAll(dotConfig, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(home, ".myapp"), []byte(real), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		dir, exist := DirFollow("myapp")
+
+		if dir != dotConfig || !exist {
+			t.Errorf("Expected '%s', true, got '%s', %v", dotConfig, dir, exist)
+		}
+	})
+}
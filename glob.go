@@ -0,0 +1,70 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// FileGlob returns the files in the configuration directory of the specified application
+// that match pattern, such as "*.conf" or "conf.d/*.conf", sorted lexically.
+//
+// The configuration directory is located using [Dir]. If it does not exist,
+// FileGlob returns an empty slice and a nil error.
+// The pattern syntax is that of [filepath.Match]; the only possible error is
+// [filepath.ErrBadPattern].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - pattern: The pattern relative to the configuration directory
+//
+// Returns:
+//   - The matching file paths
+//   - err: An error if pattern is malformed
+func FileGlob(app, pattern string) ([]string, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	dir, exist := Dir(app)
+	if !exist {
+		return []string{}, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+	if matches == nil {
+		matches = []string{}
+	}
+	slices.Sort(matches)
+	return matches, nil
+}
+
+// FragmentFiles returns the regular files in the subdirectory subdir, such as "conf.d",
+// of every existing configuration directory of the specified application.
+//
+// The files are ordered by the precedence of their configuration directory, as in [DirAll],
+// and lexically within each directory. Configuration directories without subdir are skipped.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - subdir: The subdirectory containing the fragments
+//
+// Returns:
+//   - The fragment file paths
+func FragmentFiles(app, subdir string) []string {
+	var files []string
+	for _, dir := range DirAll(app) {
+		dir = filepath.Join(dir, subdir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				files = append(files, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,129 @@
+package dotconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFileGlob(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("directory not exists", func(t *testing.T) {
+		matches, err := FileGlob("myapp", "*.conf")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if matches == nil || len(matches) != 0 {
+			t.Errorf("Expected an empty slice, got %#v", matches)
+		}
+	})
+
+	dir := filepath.Join(xdg, "myapp", "conf.d")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"b.conf", "a.conf", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("matching files", func(t *testing.T) {
+		matches, err := FileGlob("myapp", "conf.d/*.conf")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []string{filepath.Join(dir, "a.conf"), filepath.Join(dir, "b.conf")}
+		if !slices.Equal(matches, expected) {
+			t.Errorf("Expected %v, got %v", expected, matches)
+		}
+	})
+
+	t.Run("no matching files", func(t *testing.T) {
+		matches, err := FileGlob("myapp", "*.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if matches == nil || len(matches) != 0 {
+			t.Errorf("Expected an empty slice, got %#v", matches)
+		}
+	})
+
+	t.Run("bad pattern", func(t *testing.T) {
+		_, err := FileGlob("otherapp", "[")
+
+		if !errors.Is(err, filepath.ErrBadPattern) {
+			t.Errorf("Expected filepath.ErrBadPattern, got %v", err)
+		}
+	})
+}
+
+func TestFragmentFiles(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	home := filepath.Join(tmp, "home")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	user := filepath.Join(xdg, "myapp", "conf.d")
+	legacy := filepath.Join(home, ".myapp", "conf.d")
+	if err := os.MkdirAll(filepath.Join(user, "nested"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(home, "lib", "myapp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{
+		filepath.Join(user, "20-b.conf"),
+		filepath.Join(user, "10-a.conf"),
+		filepath.Join(legacy, "00-z.conf"),
+	} {
+		if err := os.WriteFile(file, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		filepath.Join(user, "10-a.conf"),
+		filepath.Join(user, "20-b.conf"),
+		filepath.Join(legacy, "00-z.conf"),
+	}
+	if got := FragmentFiles("myapp", "conf.d"); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,44 @@
+package dotconfig
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// IsUnderHome reports whether path is the user's home directory or inside it,
+// so that a caller can warn when [Dir] or [File] resolved to a location such as
+// the current directory or /etc.
+//
+// Both path and the home directory are made absolute and, when they exist,
+// have their symbolic links resolved before they are compared.
+//
+// Parameters:
+//   - path: The path to check
+//
+// Returns:
+//   - under: Boolean indicating whether path is within the home directory
+//   - err: An error if the home directory could not be determined
+func IsUnderHome(path string) (under bool, err error) {
+	home, err := homeDir()
+	if err != nil {
+		return false, fmt.Errorf("dotconfig: cannot determine home directory: %w", err)
+	}
+	home, path = canonicalPath(home), canonicalPath(path)
+	rel, err := filepath.Rel(home, path)
+	if err != nil {
+		return false, nil
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)), nil
+}
+
+// canonicalPath returns path made absolute and, if it exists, with its symbolic links resolved.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil { // if NO error
+		path = abs
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil { // if NO error
+		path = real
+	}
+	return path
+}
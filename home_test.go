@@ -0,0 +1,66 @@
+package dotconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsUnderHome(t *testing.T) {
+	// Save original functions to restore later
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	home := filepath.Join(tmp, "home")
+	if err := os.MkdirAll(filepath.Join(home, ".myapp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tmp, "link")
+	if err := os.Symlink(filepath.Join(home, ".myapp"), link); err != nil {
+		t.Skip(err)
+	}
+
+	// Mock functions
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	testCases := []struct {
+		Path     string
+		Expected bool
+	}{
+		{home, true},
+		{filepath.Join(home, ".myapp"), true},
+		{filepath.Join(home, ".config", "myapp"), true},
+		{link, true},
+		{filepath.Join(home, "..", "other"), false},
+		{home + "2", false},
+		{"/etc/myapp", false},
+	}
+
+	for _, tc := range testCases {
+		under, err := IsUnderHome(tc.Path)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if under != tc.Expected {
+			t.Errorf("Expected %v for %s, got %v", tc.Expected, tc.Path, under)
+		}
+	}
+
+	t.Run("home unavailable", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		if _, err := IsUnderHome(home); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected os.ErrNotExist, got %v", err)
+		}
+	})
+}
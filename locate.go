@@ -0,0 +1,127 @@
+package dotconfig
+
+import (
+	"context"
+	"io/fs"
+)
+
+//go:generate stringer -type Source -trimprefix Source
+
+// Source identifies the rule of the search order of [File] that produced a configuration file path.
+// Unlike [Location], it tells the dot-prefixed directories apart from the flat dot-file fallbacks.
+type Source int
+
+const (
+	// SourceXDG is $XDG_CONFIG_HOME/<app>/<name>
+	SourceXDG Source = iota
+
+	// SourceDotConfig is $HOME/.config/<app>/<name>
+	SourceDotConfig
+
+	// SourcePlan9Lib is $HOME/lib/<app>/<name>
+	SourcePlan9Lib
+
+	// SourceHomeDotDir is $HOME/.<app>/<name>
+	SourceHomeDotDir
+
+	// SourceHomeDotFile is the flat dot-file $HOME/.<app><ext>
+	SourceHomeDotFile
+
+	// SourceLocalDotDir is .<app>/<name> in the current directory
+	SourceLocalDotDir
+
+	// SourceLocalDotFile is the flat dot-file .<app><ext> in the current directory
+	SourceLocalDotFile
+
+	// SourceSnap is $SNAP_USER_COMMON/<app>/<name>
+	SourceSnap
+
+	// SourceEtc is /etc/<app>/<name>
+	SourceEtc
+
+	// SourceDotLocal is $HOME/.local/<app>/<name>
+	SourceDotLocal
+)
+
+// isDotFile reports whether s is a flat dot-file fallback rather than a file inside a directory.
+func (s Source) isDotFile() bool {
+	return s == SourceHomeDotFile || s == SourceLocalDotFile
+}
+
+// Result describes the configuration file chosen by [Locate].
+type Result struct {
+	// Path is the configuration file path.
+	Path string
+
+	// Status reports whether the file exists, only its base directory exists, or neither exists.
+	Status FileStatus
+
+	// Existed reports whether an existing file was found.
+	Existed bool
+
+	// WasFallback reports whether no existing file was found and Path is the recommended
+	// location where it should be created.
+	WasFallback bool
+}
+
+// Locate searches for a configuration file for the specified application like [File],
+// but returns a [Result] that tells an existing file apart from a recommended fallback,
+// for example to log "using existing ..." versus "no config found, will create at ...".
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - result: The chosen configuration file and how it was chosen
+//   - err: An error wrapping [fs.ErrNotExist] if no candidate location could be determined
+func Locate(app, name string) (result Result, err error) {
+	path, status, _ := newFileConfig(app, name).search(context.Background(), configLayout(), checkFile)
+	if path == "" {
+		return Result{Status: NotExists, WasFallback: true}, &fs.PathError{Op: "locate", Path: name, Err: fs.ErrNotExist}
+	}
+	existed := status == FileExists
+	return Result{Path: path, Status: status, Existed: existed, WasFallback: !existed}, nil
+}
+
+// FileResult describes the configuration file chosen by [LocateFile].
+type FileResult struct {
+	Result
+
+	// Source is the rule of the search order that produced Path.
+	Source Source
+}
+
+// LocateFile is like [Locate] but also reports the [Source] of the chosen path,
+// so that a caller can, for example, warn about the flat dot-file layout $HOME/.<app><ext>.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - result: The chosen configuration file, how it was chosen, and the rule that produced it
+//   - err: An error wrapping [fs.ErrNotExist] if no candidate location could be determined
+func LocateFile(app, name string) (result FileResult, err error) {
+	found := false
+	for source, path := range newFileConfig(app, name).Sources(configLayout()) {
+		if checkFile(path) == FileExists {
+			result.Path, result.Source, found = path, source, true
+			break
+		}
+		if result.Path == "" || result.Source == SourceLocalDotDir {
+			// Among the current-directory fallbacks, the dot-file is preferred as in File.
+			result.Path, result.Source = path, source
+		}
+	}
+	if result.Path == "" {
+		result.Status, result.WasFallback = NotExists, true
+		return result, &fs.PathError{Op: "locate", Path: name, Err: fs.ErrNotExist}
+	}
+	if found {
+		result.Status, result.Existed = FileExists, true
+	} else {
+		result.Status, result.WasFallback = checkFile(result.Path), true
+	}
+	return result, nil
+}
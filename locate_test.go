@@ -0,0 +1,138 @@
+package dotconfig
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLocate(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		result, err := Locate("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Result{Path: "/mock/home/.myapp/config.yaml", Status: FileExists, Existed: true}
+		if result != expected {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/myapp/config.yaml" {
+				return BaseExists
+			}
+			return NotExists
+		}
+
+		result, err := Locate("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Result{Path: "/mock/xdg/myapp/config.yaml", Status: BaseExists, WasFallback: true}
+		if result != expected {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	})
+}
+
+func TestLocateFile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+
+	testCases := []struct {
+		Home     string
+		Exists   string
+		Expected Source
+	}{
+		{"/mock/home", "/mock/home/.config/myapp/config.yaml", SourceDotConfig},
+		{"/mock/home", "/mock/home/.myapp/config.yaml", SourceHomeDotDir},
+		{"/mock/home", "/mock/home/.myapp.yaml", SourceHomeDotFile},
+		{"", ".myapp/config.yaml", SourceLocalDotDir},
+		{"", ".myapp.yaml", SourceLocalDotFile},
+	}
+	for _, tc := range testCases {
+		userHomeDir = func() (string, error) {
+			if tc.Home == "" {
+				return "", os.ErrNotExist
+			}
+			return tc.Home, nil
+		}
+		checkFile = func(path string) FileStatus {
+			if path == tc.Exists {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		result, err := LocateFile("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := FileResult{Result: Result{Path: tc.Exists, Status: FileExists, Existed: true}, Source: tc.Expected}
+		if result != expected {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		checkFile = func(path string) FileStatus { return NotExists }
+
+		result, err := LocateFile("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := FileResult{Result: Result{Path: "/mock/xdg/myapp/config.yaml", Status: NotExists, WasFallback: true}, Source: SourceXDG}
+		if result != expected {
+			t.Errorf("Expected %+v, got %+v", expected, result)
+		}
+	})
+}
+
+func TestSourceString(t *testing.T) {
+	if got := SourceHomeDotFile.String(); got != "HomeDotFile" {
+		t.Errorf("Expected 'HomeDotFile', got '%s'", got)
+	}
+}
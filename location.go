@@ -0,0 +1,253 @@
+package dotconfig
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate stringer -type Location
+
+// Location identifies a rule of the search order used by [Dir] and [File].
+type Location int
+
+const (
+	// XDG is $XDG_CONFIG_HOME/<app>, searched when XDG_CONFIG_HOME is set
+	XDG Location = iota
+
+	// DotConfig is $HOME/.config/<app>, searched when XDG_CONFIG_HOME is not set
+	DotConfig
+
+	// Plan9Lib is $HOME/lib/<app>, for Plan9 compatibility.
+	// It is searched only on Plan9 unless [WithPlan9Compat] is given.
+	Plan9Lib
+
+	// HomeDot is $HOME/.<app>, and also $HOME/.<app><ext> for files
+	HomeDot
+
+	// LocalDot is .<app> in the current directory, and also .<app><ext> for files
+	LocalDot
+
+	// Snap is $SNAP_USER_COMMON/<app>, searched only when running inside a Snap,
+	// that is, when both SNAP and SNAP_USER_COMMON are set
+	Snap
+
+	// Etc is /etc/<app>, the system-wide configuration of a service.
+	// It is not in the default search order; see [WithEtc]
+	Etc
+
+	// DotLocal is $HOME/.local/<app>, holding machine-local configuration.
+	// It is not in the default search order; see [WithDotLocal]
+	DotLocal
+)
+
+// DefaultSearchOrder returns the search order used by [Dir] and [File].
+func DefaultSearchOrder() []Location {
+	return []Location{Snap, XDG, DotConfig, Plan9Lib, HomeDot, LocalDot}
+}
+
+// layout describes how the candidate locations of an application are built.
+type layout struct {
+	xdgHome func() string          // returns the XDG base directory, or "" if it is not set
+	home    func() (string, error) // returns the home directory; homeDir if nil
+	rel     string                 // the directory under $HOME used when the XDG base directory is not set
+	order   []Location
+	plan9   bool          // whether the Plan9Lib location is searched
+	snap    func() string // returns the Snap base directory, or "" if it is not set; the location is skipped if nil
+	ns      string        // the namespace directory containing app, or "" for none
+}
+
+func configLayout() layout {
+	return layout{xdgHome: xdgConfigHome, rel: ".config", order: DefaultSearchOrder(), plan9: plan9Lib, snap: snapUserCommon}
+}
+
+var snapUserCommon = defaultSnapUserCommon
+
+// defaultSnapUserCommon returns $SNAP_USER_COMMON when running inside a Snap, or "" otherwise.
+func defaultSnapUserCommon() string {
+	if os.Getenv("SNAP") == "" {
+		return ""
+	}
+	return os.Getenv("SNAP_USER_COMMON")
+}
+
+var plan9Lib = plan9Compat
+
+func (l layout) homeDir() (string, error) {
+	if l.home != nil {
+		return l.home()
+	}
+	return homeDir()
+}
+
+// cacheHome returns a copy of l that resolves the home directory at most once,
+// however many times its locations are iterated, and only when it is first needed.
+func (l layout) cacheHome() layout {
+	var home string
+	var homeErr error
+	resolved := false
+	homeDir := l.homeDir
+	l.home = func() (string, error) {
+		if !resolved {
+			home, homeErr = homeDir()
+			resolved = true
+		}
+		return home, homeErr
+	}
+	return l
+}
+
+// locations yields each location of l.order along with the directory of app at that location.
+// Locations that cannot be determined, such as those under an unavailable home directory, are skipped.
+// Nothing is yielded if app or the namespace is not a valid application name, see [layout.valid].
+func (l layout) locations(app string) iter.Seq2[Location, string] {
+	valid := l.valid(app)
+	app = normalizeApp(app)
+	nested := filepath.Join(l.ns, app)
+	return func(yield func(Location, string) bool) {
+		if !valid {
+			return
+		}
+		xdg := l.xdgHome()
+		var home string
+		var homeErr error
+		resolved := false
+		hasHome := func() bool {
+			if !resolved {
+				home, homeErr = l.homeDir()
+				resolved = true
+			}
+			return homeErr == nil
+		}
+		for _, loc := range l.order {
+			var dir string
+			switch loc {
+			case Snap:
+				if l.snap == nil {
+					continue
+				}
+				base := l.snap()
+				if base == "" {
+					continue
+				}
+				dir = filepath.Join(base, nested)
+			case XDG:
+				if xdg == "" {
+					continue
+				}
+				dir = filepath.Join(xdg, nested)
+			case DotConfig:
+				if xdg != "" || !hasHome() {
+					continue
+				}
+				dir = filepath.Join(home, l.rel, nested)
+			case Plan9Lib:
+				if !l.plan9 || !hasHome() {
+					continue
+				}
+				dir = filepath.Join(home, "lib", nested)
+			case HomeDot:
+				if !hasHome() {
+					continue
+				}
+				dir = filepath.Join(home, l.dotDir(app))
+			case LocalDot:
+				dir = l.dotDir(app)
+			case Etc:
+				dir = filepath.Join("/etc", nested)
+			case DotLocal:
+				if !hasHome() {
+					continue
+				}
+				dir = filepath.Join(home, ".local", nested)
+			default:
+				continue
+			}
+			if !yield(loc, dir) {
+				return
+			}
+		}
+	}
+}
+
+// valid reports whether app, once normalized, and the namespace of l pass [ValidateApp].
+// Names with empty, "." or ".." segments are not valid, so that no location built from them
+// can escape the configuration directories, as Dir("..") would otherwise do.
+func (l layout) valid(app string) bool {
+	if l.ns != "" && ValidateApp(l.ns) != nil {
+		return false
+	}
+	return ValidateApp(normalizeApp(app)) == nil
+}
+
+// dotDir returns the dot-prefixed directory of app relative to the home or current directory:
+// .<app>, or .<ns>/<app> when a namespace is set.
+func (l layout) dotDir(app string) string {
+	if l.ns == "" {
+		return dotName(app)
+	}
+	return filepath.Join(dotName(l.ns), normalizeApp(app))
+}
+
+// dirs returns the candidate directories of app and the current-directory fallback.
+// As in [Dir], a trailing LocalDot is not searched in order but used as the last resort
+// when no other candidate could be determined; local is empty if there is no such fallback.
+func (l layout) dirs(app string) (candidates iter.Seq[string], local string) {
+	if l.hasFallback() && l.valid(app) {
+		local = l.dotDir(app)
+	}
+	l = l.searched()
+	return func(yield func(string) bool) {
+		for _, dir := range l.locations(app) {
+			if !yield(dir) {
+				return
+			}
+		}
+	}, local
+}
+
+// hasFallback reports whether l ends with LocalDot, which is used only as the last resort.
+func (l layout) hasFallback() bool {
+	return len(l.order) > 0 && l.order[len(l.order)-1] == LocalDot
+}
+
+// searched returns l without its last-resort LocalDot, that is, the locations searched in order.
+func (l layout) searched() layout {
+	if l.hasFallback() {
+		l.order = l.order[:len(l.order)-1]
+	}
+	return l
+}
+
+// Classify reports which location of the search order of [Dir] and [File] the given path,
+// such as one returned by [Dir] or [File], corresponds to for the specified application,
+// so that a user interface can show "using XDG config" or "using legacy ~/.myapp".
+//
+// The path is matched against the candidates of the default search order rather than parsed:
+// it matches a location if it is the directory of that location, a path inside it,
+// or its dot-prefixed file fallback such as $HOME/.<app>.yaml.
+// When the path matches no location, ok is false.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - path: The path to classify
+//
+// Returns:
+//   - loc: The location the path corresponds to
+//   - ok: Boolean indicating whether the path corresponds to any location
+func Classify(app, path string) (loc Location, ok bool) {
+	path = filepath.Clean(path)
+	dot := dotName(app)
+	for loc, dir := range configLayout().locations(app) {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return loc, true
+		}
+		if loc == HomeDot || loc == LocalDot {
+			if filepath.Dir(path) == filepath.Dir(dir) && strings.HasPrefix(filepath.Base(path), dot+".") {
+				return loc, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,415 @@
+package dotconfig
+
+import (
+	"os"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestDefaultSearchOrder(t *testing.T) {
+	expected := []Location{Snap, XDG, DotConfig, Plan9Lib, HomeDot, LocalDot}
+	order := DefaultSearchOrder()
+	if !slices.Equal(order, expected) {
+		t.Errorf("Expected %v, got %v", expected, order)
+	}
+
+	// The returned slice must be a fresh copy.
+	order[0] = LocalDot
+	if DefaultSearchOrder()[0] != Snap {
+		t.Error("Expected DefaultSearchOrder to return a new slice")
+	}
+}
+
+func TestLocation_String(t *testing.T) {
+	testCases := []struct {
+		Location Location
+		Expected string
+	}{
+		{XDG, "XDG"},
+		{DotConfig, "DotConfig"},
+		{Plan9Lib, "Plan9Lib"},
+		{HomeDot, "HomeDot"},
+		{LocalDot, "LocalDot"},
+		{Snap, "Snap"},
+		{Etc, "Etc"},
+		{DotLocal, "DotLocal"},
+		{Location(42), "Location(42)"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.Location.String(); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestLayout_Locations(t *testing.T) {
+	// Save original functions to restore later
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("home resolved once", func(t *testing.T) {
+		called := 0
+		userHomeDir = func() (string, error) {
+			called++
+			return "/mock/home", nil
+		}
+
+		l := layout{xdgHome: func() string { return "" }, rel: ".config", order: DefaultSearchOrder(), plan9: true}
+		locations := []Location{}
+		for loc := range l.locations("myapp") {
+			locations = append(locations, loc)
+		}
+
+		expected := []Location{DotConfig, Plan9Lib, HomeDot, LocalDot}
+		if !slices.Equal(locations, expected) {
+			t.Errorf("Expected %v, got %v", expected, locations)
+		}
+		if called != 1 {
+			t.Errorf("Expected userHomeDir to be called 1 time, got %d", called)
+		}
+	})
+
+	t.Run("home not resolved with XDG and early termination", func(t *testing.T) {
+		called := 0
+		userHomeDir = func() (string, error) {
+			called++
+			return "/mock/home", nil
+		}
+
+		l := layout{xdgHome: func() string { return "/mock/xdg" }, rel: ".config", order: DefaultSearchOrder(), plan9: true}
+		for range l.locations("myapp") {
+			break
+		}
+
+		if called != 0 {
+			t.Errorf("Expected userHomeDir to be called 0 times, got %d", called)
+		}
+	})
+
+	t.Run("home unavailable", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		l := layout{xdgHome: func() string { return "/mock/xdg" }, rel: ".config", order: DefaultSearchOrder(), plan9: true}
+		locations := []Location{}
+		for loc := range l.locations("myapp") {
+			locations = append(locations, loc)
+		}
+
+		expected := []Location{XDG, LocalDot}
+		if !slices.Equal(locations, expected) {
+			t.Errorf("Expected %v, got %v", expected, locations)
+		}
+	})
+
+	t.Run("Plan9Lib disabled", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		l := layout{xdgHome: func() string { return "" }, rel: ".config", order: DefaultSearchOrder()}
+		locations := []Location{}
+		for loc := range l.locations("myapp") {
+			locations = append(locations, loc)
+		}
+
+		expected := []Location{DotConfig, HomeDot, LocalDot}
+		if !slices.Equal(locations, expected) {
+			t.Errorf("Expected %v, got %v", expected, locations)
+		}
+	})
+}
+
+func TestPlan9LibDefault(t *testing.T) {
+	if expected := runtime.GOOS == "plan9"; plan9Compat != expected {
+		t.Errorf("Expected plan9Compat to be %v on %s, got %v", expected, runtime.GOOS, plan9Compat)
+	}
+}
+
+func TestWithSearchOrder(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("local dir wins over home .config", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == ".myapp" || dir == "/mock/home/.config/myapp"
+		}
+
+		dir, exist := DirWith("myapp", WithSearchOrder([]Location{LocalDot, DotConfig, HomeDot}))
+
+		if dir != ".myapp" {
+			t.Errorf("Expected dir to be '.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("subset of locations", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.config/myapp"
+		}
+
+		dir, exist := DirWith("myapp", WithSearchOrder([]Location{HomeDot}))
+
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("no locations determined", func(t *testing.T) {
+		dirExists = func(dir string) bool { return true }
+
+		dir, exist := DirWith("myapp", WithSearchOrder([]Location{XDG}))
+
+		if dir != "" {
+			t.Errorf("Expected dir to be empty, got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("file candidates follow the order", func(t *testing.T) {
+		checkFile = func(path string) FileStatus { return NotExists }
+
+		cfg := newFileConfig("myapp", "config.yaml")
+		l := configLayout()
+		l.order = []Location{LocalDot, HomeDot, DotConfig}
+		paths := []string{}
+		for path := range cfg.ListIn(l) {
+			paths = append(paths, path)
+		}
+
+		expectedPaths := []string{
+			".myapp/config.yaml",
+			".myapp.yaml",
+			"/mock/home/.myapp/config.yaml",
+			"/mock/home/.myapp.yaml",
+			"/mock/home/.config/myapp/config.yaml",
+		}
+		if !slices.Equal(paths, expectedPaths) {
+			t.Errorf("Expected %v, got %v", expectedPaths, paths)
+		}
+
+		path, status := FileWith("myapp", "config.yaml", WithSearchOrder(l.order))
+		if path != ".myapp/config.yaml" {
+			t.Errorf("Expected path to be '.myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("default order matches Dir", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/lib/myapp"
+		}
+
+		dir, exist := DirWith("myapp", WithSearchOrder(DefaultSearchOrder()))
+		expectedDir, expectedExist := Dir("myapp")
+
+		if dir != expectedDir {
+			t.Errorf("Expected dir to be '%s', got '%s'", expectedDir, dir)
+		}
+		if exist != expectedExist {
+			t.Errorf("Expected exist to be %v, got %v", expectedExist, exist)
+		}
+	})
+}
+
+func TestSnapLocation(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+	origSnapUserCommon := snapUserCommon
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+		snapUserCommon = origSnapUserCommon
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool { return false }
+	checkFile = func(path string) FileStatus { return NotExists }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("inside a Snap", func(t *testing.T) {
+		snapUserCommon = func() string { return "/mock/snap/common" }
+
+		dir, _ := Dir("myapp")
+		if dir != "/mock/snap/common/myapp" {
+			t.Errorf("Expected dir to be '/mock/snap/common/myapp', got '%s'", dir)
+		}
+		path, _ := File("myapp", "config.yaml")
+		if path != "/mock/snap/common/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/snap/common/myapp/config.yaml', got '%s'", path)
+		}
+		data, _ := DataDir("myapp")
+		if data == "/mock/snap/common/myapp" {
+			t.Errorf("Expected data dir not to use the Snap location, got '%s'", data)
+		}
+	})
+
+	t.Run("outside a Snap", func(t *testing.T) {
+		snapUserCommon = func() string { return "" }
+
+		dir, _ := Dir("myapp")
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+	})
+}
+
+func TestDefaultSnapUserCommon(t *testing.T) {
+	t.Setenv("SNAP_USER_COMMON", "/mock/snap/common")
+
+	t.Setenv("SNAP", "")
+	if got := defaultSnapUserCommon(); got != "" {
+		t.Errorf("Expected '' outside a Snap, got '%s'", got)
+	}
+
+	t.Setenv("SNAP", "/snap/myapp/1")
+	if got := defaultSnapUserCommon(); got != "/mock/snap/common" {
+		t.Errorf("Expected '/mock/snap/common', got '%s'", got)
+	}
+}
+
+func TestHomeResolvedOncePerCall(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	called := 0
+	userHomeDir = func() (string, error) {
+		called++
+		return "/mock/home", nil
+	}
+	dirExists = func(dir string) bool { return false }
+	checkFile = func(path string) FileStatus { return NotExists }
+
+	t.Run("without XDG", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+
+		testCases := []struct {
+			Name string
+			Call func()
+		}{
+			{"Dir", func() { Dir("myapp") }},
+			{"File", func() { File("myapp", "config.yaml") }},
+			{"FileExt", func() { FileExt("myapp", "config", ".yaml", ".json", ".toml") }},
+		}
+		for _, tc := range testCases {
+			called = 0
+			tc.Call()
+			if called != 1 {
+				t.Errorf("Expected %s to call userHomeDir 1 time, got %d", tc.Name, called)
+			}
+		}
+	})
+
+	t.Run("found under XDG", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/xdg/myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		called = 0
+		File("myapp", "config.yaml")
+		if called != 0 {
+			t.Errorf("Expected File to call userHomeDir 0 times, got %d", called)
+		}
+	})
+}
+
+func TestClassify(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	testCases := []struct {
+		XDG      string
+		Path     string
+		Expected Location
+		OK       bool
+	}{
+		{"/mock/xdg", "/mock/xdg/myapp", XDG, true},
+		{"/mock/xdg", "/mock/xdg/myapp/config.yaml", XDG, true},
+		{"", "/mock/home/.config/myapp/", DotConfig, true},
+		{"", "/mock/home/lib/myapp", Plan9Lib, true},
+		{"", "/mock/home/.myapp", HomeDot, true},
+		{"", "/mock/home/.myapp.yaml", HomeDot, true},
+		{"", ".myapp/config.yaml", LocalDot, true},
+		{"", ".myapp.yaml", LocalDot, true},
+		{"", "/mock/home/.myapplication", 0, false},
+		{"", "/mock/home/.config/otherapp", 0, false},
+		{"/mock/xdg", "/mock/home/.config/myapp", 0, false},
+	}
+	for _, tc := range testCases {
+		xdgConfigHome = func() string { return tc.XDG }
+		loc, ok := Classify("myapp", tc.Path)
+		if loc != tc.Expected || ok != tc.OK {
+			t.Errorf("Expected %v, %v for %q, got %v, %v", tc.Expected, tc.OK, tc.Path, loc, ok)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package dotconfig
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain enables the Plan9Lib location regardless of the build,
+// so that the tests cover the full search order on every platform.
+// Tests of the platform default set plan9Lib explicitly.
+// It also ignores a Snap environment the tests may happen to run in.
+func TestMain(m *testing.M) {
+	plan9Lib = true
+	snapUserCommon = func() string { return "" }
+	os.Exit(m.Run())
+}
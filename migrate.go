@@ -0,0 +1,42 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Migrate moves a legacy $HOME/.<app> configuration directory to the preferred location,
+// the one returned by [RecommendDir], such as $XDG_CONFIG_HOME/<app> or $HOME/.config/<app>.
+//
+// It is a no-op when the legacy directory does not exist, when the home directory cannot be
+// determined, when app is not valid according to [ValidateApp], when the preferred directory already exists,
+// so that an existing configuration is never clobbered, or when the preferred directory is inside the legacy one,
+// as for Migrate("config"), whose legacy directory is $HOME/.config itself. Missing parents of the preferred directory are created with the permission [DefaultDirPerm].
+// The directory is moved with [os.Rename], which fails if the two locations are on different file systems.
+//
+// Parameters:
+//   - app: The application name whose configuration is migrated
+//
+// Returns:
+//   - from: The legacy directory path, or "" if the home directory cannot be determined or app is not valid
+//   - to: The preferred directory path
+//   - migrated: Boolean indicating whether the directory was moved
+//   - err: An error if the directory could not be moved
+func Migrate(app string) (from, to string, migrated bool, err error) {
+	to = RecommendDir(app)
+	home, err := homeDir()
+	if err != nil || localDir(app) == "" {
+		return "", to, false, nil
+	}
+	from = filepath.Join(home, dotName(app))
+	if contains(from, to) || !dirExists(from) || dirExists(to) {
+		return from, to, false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(to), DefaultDirPerm); err != nil {
+		return from, to, false, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return from, to, false, err
+	}
+	return from, to, true, nil
+}
@@ -0,0 +1,126 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	home := filepath.Join(tmp, "home")
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+	legacy := filepath.Join(home, ".myapp")
+	preferred := filepath.Join(home, ".config", "myapp")
+
+	t.Run("no legacy directory", func(t *testing.T) {
+		from, to, migrated, err := Migrate("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if from != legacy || to != preferred {
+			t.Errorf("Expected '%s' -> '%s', got '%s' -> '%s'", legacy, preferred, from, to)
+		}
+		if migrated {
+			t.Error("Expected migrated to be false")
+		}
+	})
+
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "config.yaml"), []byte("legacy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("move legacy directory", func(t *testing.T) {
+		_, _, migrated, err := Migrate("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !migrated {
+			t.Error("Expected migrated to be true")
+		}
+		if DirExists(legacy) {
+			t.Error("Expected legacy directory to be moved away")
+		}
+		data, err := os.ReadFile(filepath.Join(preferred, "config.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "legacy" {
+			t.Errorf("Expected data to be 'legacy', got '%s'", data)
+		}
+	})
+
+	t.Run("preferred directory exists", func(t *testing.T) {
+		if err := os.MkdirAll(legacy, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, migrated, err := Migrate("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if migrated {
+			t.Error("Expected migrated to be false")
+		}
+		if !DirExists(legacy) {
+			t.Error("Expected legacy directory to be kept")
+		}
+	})
+
+	t.Run("preferred directory inside legacy directory", func(t *testing.T) {
+		// The legacy directory of "config" is $HOME/.config, which contains $HOME/.config/config.
+		other := filepath.Join(home, ".config", "otherapp")
+		if err := os.MkdirAll(other, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		from, to, migrated, err := Migrate("config")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if migrated {
+			t.Error("Expected migrated to be false")
+		}
+		if expected := filepath.Join(home, ".config"); from != expected {
+			t.Errorf("Expected from to be '%s', got '%s'", expected, from)
+		}
+		if DirExists(to) || !DirExists(other) {
+			t.Error("Expected $HOME/.config to be left untouched")
+		}
+	})
+
+	t.Run("home unavailable", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		from, _, migrated, err := Migrate("otherapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if from != "" || migrated {
+			t.Errorf("Expected no migration, got from '%s' and migrated %v", from, migrated)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package dotconfig
+
+import (
+	"fmt"
+)
+
+// MustDir is like [Dir] but panics when neither XDG_CONFIG_HOME nor the home directory
+// could be determined, that is, when the result would fall through to the
+// current-directory fallback. It also panics with the error of [ValidateApp] when app is not valid,
+// instead of returning an unusable empty path.
+//
+// It is intended for programs that treat the lack of a usable configuration location as fatal.
+func MustDir(app string) string {
+	if err := ValidateApp(normalizeApp(app)); err != nil {
+		panic(err)
+	}
+	if err := checkLocatable(); err != nil {
+		panic(err)
+	}
+	dir, _ := Dir(app)
+	return dir
+}
+
+// MustFile is like [File] but panics when neither XDG_CONFIG_HOME nor the home directory
+// could be determined, that is, when the result would fall through to the
+// current-directory fallbacks. It also panics with the error of [ValidateApp] when app is not valid,
+// instead of returning an unusable empty path.
+//
+// It is intended for programs that treat the lack of a usable configuration location as fatal.
+func MustFile(app, name string) string {
+	if err := ValidateApp(normalizeApp(app)); err != nil {
+		panic(err)
+	}
+	if err := checkLocatable(); err != nil {
+		panic(err)
+	}
+	path, _ := File(app, name)
+	return path
+}
+
+// checkLocatable returns an error if no location other than the current directory can be searched.
+func checkLocatable() error {
+	if xdgConfigHome() != "" {
+		return nil
+	}
+	if _, err := homeDir(); err != nil {
+		return fmt.Errorf("dotconfig: cannot determine configuration location: XDG_CONFIG_HOME is not set and home directory is unavailable: %w", err)
+	}
+	return nil
+}
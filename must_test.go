@@ -0,0 +1,126 @@
+package dotconfig
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestMustDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	dirExists = func(dir string) bool { return false }
+
+	t.Run("home available", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		if dir := MustDir("myapp"); dir != "/mock/home/.config/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+		}
+	})
+
+	t.Run("XDG available without home", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		if dir := MustDir("myapp"); dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+	})
+
+	t.Run("No locations available", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected panic wrapping os.ErrNotExist, got %v", err)
+			}
+		}()
+		MustDir("myapp")
+	})
+
+	t.Run("Invalid app", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, ErrInvalidApp) {
+				t.Errorf("Expected panic wrapping ErrInvalidApp, got %v", err)
+			}
+		}()
+		MustDir("..")
+	})
+}
+
+func TestMustFile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	checkFile = func(path string) FileStatus { return NotExists }
+
+	t.Run("home available", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		if path := MustFile("myapp", "config.yaml"); path != "/mock/home/.config/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.config/myapp/config.yaml', got '%s'", path)
+		}
+	})
+
+	t.Run("No locations available", func(t *testing.T) {
+		xdgConfigHome = func() string { return "" }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected panic wrapping os.ErrNotExist, got %v", err)
+			}
+		}()
+		MustFile("myapp", "config.yaml")
+	})
+
+	t.Run("Invalid app", func(t *testing.T) {
+		xdgConfigHome = func() string { return "/mock/xdg" }
+
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, ErrInvalidApp) {
+				t.Errorf("Expected panic wrapping ErrInvalidApp, got %v", err)
+			}
+		}()
+		MustFile("..", "config.yaml")
+	})
+}
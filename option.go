@@ -0,0 +1,427 @@
+package dotconfig
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Option configures the behavior of [DirWith] and [FileWith].
+type Option func(*options)
+
+type options struct {
+	resolveSymlinks bool
+	expandEnv       bool
+	lowercaseApp    bool
+	order           []Location
+	noLocal         bool
+	home            string
+	caseInsensitive bool
+	plan9           *bool
+	configBase      string
+	etc             bool
+	dotLocal        bool
+	pollInterval    time.Duration
+	namespace       string
+	ext             string
+	defaultExt      string
+	absolute        bool
+	deepest         bool
+	dirPerm         os.FileMode
+	filePerm        os.FileMode
+	probeLogger     func(path string, status FileStatus)
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		dirPerm:      DefaultDirPerm,
+		filePerm:     DefaultFilePerm,
+		pollInterval: defaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithResolveSymlinks makes the returned path the result of [filepath.EvalSymlinks]
+// when the chosen path exists.
+//
+// If EvalSymlinks fails, for example because of a broken link, the lexical path is returned.
+// Recommended paths that do not exist are returned unchanged.
+func WithResolveSymlinks() Option {
+	return func(o *options) {
+		o.resolveSymlinks = true
+	}
+}
+
+// WithAbsolute makes [DirWith] and [FileWith] return an absolute path, made with [filepath.Abs],
+// even for the current-directory fallbacks, so that the path stays valid after a change
+// of the working directory. Existence is determined as without the option.
+//
+// It is off by default since some callers display the relative form.
+func WithAbsolute() Option {
+	return func(o *options) {
+		o.absolute = true
+	}
+}
+
+// WithDeepestExistingAncestor makes [DirWith] and [FileWith], when nothing exists yet,
+// recommend the candidate that needs the fewest directories to be created, instead of the first one.
+// Among candidates needing as many, the one searched first is chosen.
+// Only candidates in the same tier as the first one are compared: the current directory is a tier of
+// its own, and the dot-prefixed file fallbacks such as $HOME/.<app><ext> are never recommended.
+//
+// This tends to keep a new configuration next to existing related directories,
+// such as an existing $HOME/.config, rather than in a fresh directory tree.
+func WithDeepestExistingAncestor() Option {
+	return func(o *options) {
+		o.deepest = true
+	}
+}
+
+// WithDirPerm sets the permission used by [EnsureDir] and [WriteFile] to create directories.
+// The default is [DefaultDirPerm].
+func WithDirPerm(perm os.FileMode) Option {
+	return func(o *options) {
+		o.dirPerm = perm
+	}
+}
+
+// WithFilePerm sets the permission used by [WriteFile] to create files.
+// The default is [DefaultFilePerm].
+func WithFilePerm(perm os.FileMode) Option {
+	return func(o *options) {
+		o.filePerm = perm
+	}
+}
+
+// WithPollInterval sets how often [Watch] checks the configuration file for changes.
+// The default is one second. A non-positive d is ignored and keeps the previous interval.
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// WithExpandEnv makes the application and file names be expanded with [os.ExpandEnv]
+// before any path is constructed, so that a name like "myapp-$PROFILE" can select a profile.
+// Unset variables expand to the empty string.
+//
+// It is off by default, since "$" may legitimately appear in a name.
+func WithExpandEnv() Option {
+	return func(o *options) {
+		o.expandEnv = true
+	}
+}
+
+// WithLowercaseApp makes [DirWith] and [FileWith] lowercase the application name,
+// so that code paths passing "MyApp" and "myapp" never create two configuration directories,
+// which matters on case-insensitive filesystems such as the defaults of macOS and Windows.
+//
+// It only affects how paths are constructed; the file name is left as is.
+func WithLowercaseApp() Option {
+	return func(o *options) {
+		o.lowercaseApp = true
+	}
+}
+
+// WithSearchOrder sets the locations searched by [DirWith] and [FileWith] and their order.
+// Locations not in order are not searched, which allows a subset of the default locations.
+// For example, placing [LocalDot] first lets a project-local configuration override the user configuration.
+//
+// As in [Dir], a trailing [LocalDot] is used only as the last resort
+// when no other location could be determined; anywhere else it is searched in order.
+// See [DefaultSearchOrder] for the default order.
+func WithSearchOrder(order []Location) Option {
+	order = slices.Clone(order)
+	return func(o *options) {
+		o.order = order
+	}
+}
+
+// WithNoLocalFallback makes [DirWith] and [FileWith] never consider the current directory,
+// so that a configuration planted in the working directory cannot be picked up.
+//
+// When neither XDG_CONFIG_HOME nor the home directory could be determined,
+// the returned path is empty and the directory or file is reported as not existing.
+func WithNoLocalFallback() Option {
+	return func(o *options) {
+		o.noLocal = true
+	}
+}
+
+// WithHome makes [DirWith] and [FileWith] search under home instead of the directory
+// returned by [os.UserHomeDir], without modifying the environment.
+// The given home is always considered available.
+func WithHome(home string) Option {
+	return func(o *options) {
+		o.home = home
+	}
+}
+
+// WithCaseInsensitiveMatch makes [DirWith] match existing directories case-insensitively,
+// so that an existing "MyApp" is found when the application name is "myapp".
+//
+// When a candidate directory does not exist, its parent directory is scanned
+// for an entry whose name matches case-insensitively, and the on-disk name is returned.
+// It is off by default since it costs a directory read for each missing candidate.
+func WithCaseInsensitiveMatch() Option {
+	return func(o *options) {
+		o.caseInsensitive = true
+	}
+}
+
+// WithPlan9Compat makes [DirWith] and [FileWith] search the [Plan9Lib] location,
+// $HOME/lib/<app>, which is otherwise searched only on Plan9.
+func WithPlan9Compat() Option {
+	return func(o *options) {
+		enabled := true
+		o.plan9 = &enabled
+	}
+}
+
+// WithoutPlan9 makes [DirWith] and [FileWith] skip the [Plan9Lib] location, $HOME/lib/<app>,
+// even on Plan9, for home directories where ~/lib holds unrelated directories.
+// Of WithoutPlan9 and [WithPlan9Compat], the one given last takes effect.
+// The rest of the search order, including one set by [WithSearchOrder], is unchanged.
+func WithoutPlan9() Option {
+	return func(o *options) {
+		disabled := false
+		o.plan9 = &disabled
+	}
+}
+
+// WithConfigBaseName makes [DirWith] and [FileWith] use name in place of ".config"
+// for the [DotConfig] location, $HOME/.config/<app>, searched when XDG_CONFIG_HOME is not set.
+// This allows coexisting with a legacy layout such as $HOME/.settings/<app>.
+func WithConfigBaseName(name string) Option {
+	return func(o *options) {
+		o.configBase = name
+	}
+}
+
+// WithEtc makes [DirWith] and [FileWith] also search the [Etc] location, /etc/<app>,
+// after every other location except the current-directory fallback, which stays the last resort.
+// It is off by default since it only makes sense for system services.
+func WithEtc() Option {
+	return func(o *options) {
+		o.etc = true
+	}
+}
+
+// WithDotLocal makes [DirWith] and [FileWith] also search the [DotLocal] location, $HOME/.local/<app>,
+// right before $HOME/.<app>, where some tools keep machine-local configuration.
+// It is off by default to preserve the default search order.
+func WithDotLocal() Option {
+	return func(o *options) {
+		o.dotLocal = true
+	}
+}
+
+// WithNamespace makes [DirWith] and [FileWith] place the application under the namespace
+// directory ns, so that a suite of related tools shares a parent directory,
+// as in $XDG_CONFIG_HOME/<ns>/<app>.
+//
+// The dot-prefixed locations become .<ns>/<app>, and the dot-prefixed file fallbacks
+// become .<ns>/<app><ext>. An empty ns leaves the behavior unchanged.
+func WithNamespace(ns string) Option {
+	return func(o *options) {
+		o.namespace = normalizeApp(ns)
+	}
+}
+
+// WithExtension makes [FileWith] use ext, such as ".conf", as the extension of the
+// dot-prefixed file fallbacks .<app><ext>, instead of the one inferred from the file name.
+// An empty ext restores the inferred extension.
+func WithExtension(ext string) Option {
+	return func(o *options) {
+		o.ext = ext
+	}
+}
+
+// WithProbeLogger makes [DirWith] and [FileWith] call logger for every candidate they probe,
+// in search order, with its path and the outcome of the probe, so that discovery can be
+// traced without this package depending on a logging library.
+// A directory is reported as [FileExists] or, like [DirStatus], [BaseExists] or [NotExists].
+//
+// The logger only observes the search and does not change its result.
+func WithProbeLogger(logger func(path string, status FileStatus)) Option {
+	return func(o *options) {
+		o.probeLogger = logger
+	}
+}
+
+// dirExists returns the function probing candidate directories, reporting each probe
+// to the probe logger if one is configured by o.
+func (o *options) dirExists() func(string) bool {
+	if o.probeLogger == nil {
+		return dirExists
+	}
+	return func(dir string) bool {
+		status := checkDir(dir)
+		o.probeLogger(dir, status)
+		return status == FileExists
+	}
+}
+
+// checkFile returns the function probing candidate files, reporting each probe
+// to the probe logger if one is configured by o.
+func (o *options) checkFile() func(string) FileStatus {
+	if o.probeLogger == nil {
+		return checkFile
+	}
+	return func(path string) FileStatus {
+		status := checkFile(path)
+		o.probeLogger(path, status)
+		return status
+	}
+}
+
+// WithDefaultExt makes [FileWith] append ext, such as ".yaml", to a file name that has no extension,
+// so that FileWith("myapp", "config", WithDefaultExt(".yaml")) searches for config.yaml.
+// The dot-prefixed file fallbacks then become .<app><ext> too.
+// A name derived from the application name, as with ".", is extended the same way.
+func WithDefaultExt(ext string) Option {
+	return func(o *options) {
+		o.defaultExt = ext
+	}
+}
+
+// matchCase yields each of dirs, replaced by an existing directory in the same parent
+// whose name matches case-insensitively if it does not exist itself.
+func (o *options) matchCase(dirs iter.Seq[string]) iter.Seq[string] {
+	if !o.caseInsensitive {
+		return dirs
+	}
+	return func(yield func(string) bool) {
+		for dir := range dirs {
+			if !dirExists(dir) {
+				dir = findFold(dir)
+			}
+			if !yield(dir) {
+				return
+			}
+		}
+	}
+}
+
+// findFold returns the path of a directory in the parent of dir whose name matches
+// the base name of dir case-insensitively, or dir itself if there is none.
+func findFold(dir string) string {
+	parent, base := filepath.Split(dir)
+	entries, err := os.ReadDir(filepath.Clean(parent))
+	if err != nil {
+		return dir
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.EqualFold(entry.Name(), base) {
+			return filepath.Join(parent, entry.Name())
+		}
+	}
+	return dir
+}
+
+// fewestMissing returns the candidate whose directory, as given by dir, has the fewest missing
+// directories, preferring earlier candidates on ties, or "" if there are no candidates.
+// Only candidates in the same tier as the first one are compared, so that a candidate in the
+// current directory never replaces one under the home directory, nor the other way around.
+func fewestMissing(candidates iter.Seq2[Location, string], dir func(string) string) string {
+	best, fewest, local := "", -1, false
+	for loc, candidate := range candidates {
+		if fewest < 0 {
+			local = loc == LocalDot
+		} else if (loc == LocalDot) != local {
+			continue
+		}
+		if n := len(missingDirs(dir(candidate))); fewest < 0 || n < fewest {
+			best, fewest = candidate, n
+		}
+	}
+	return best
+}
+
+// layout returns the layout of the candidate locations configured by o.
+func (o *options) layout() layout {
+	l := configLayout()
+	if o.order != nil {
+		l.order = o.order
+	}
+	if o.home != "" {
+		home := o.home
+		l.home = func() (string, error) { return home, nil }
+	}
+	l.ns = o.namespace
+	if o.configBase != "" {
+		l.rel = o.configBase
+	}
+	if o.plan9 != nil {
+		l.plan9 = *o.plan9
+	}
+	if o.dotLocal && !slices.Contains(l.order, DotLocal) {
+		order := slices.Clone(l.order)
+		if i := slices.Index(order, HomeDot); i >= 0 {
+			l.order = slices.Insert(order, i, DotLocal)
+		} else if n := len(order); n > 0 && order[n-1] == LocalDot {
+			l.order = slices.Insert(order, n-1, DotLocal)
+		} else {
+			l.order = append(order, DotLocal)
+		}
+	}
+	if o.etc && !slices.Contains(l.order, Etc) {
+		order := slices.Clone(l.order)
+		if n := len(order); n > 0 && order[n-1] == LocalDot {
+			l.order = slices.Insert(order, n-1, Etc)
+		} else {
+			l.order = append(order, Etc)
+		}
+	}
+	if o.noLocal {
+		l.order = slices.DeleteFunc(slices.Clone(l.order), func(loc Location) bool {
+			return loc == LocalDot
+		})
+	}
+	return l
+}
+
+// name applies the name transformations configured by o to an application or file name.
+func (o *options) name(name string) string {
+	if o.expandEnv {
+		name = os.ExpandEnv(name)
+	}
+	return name
+}
+
+// app applies the transformations configured by o to an application name.
+func (o *options) app(app string) string {
+	app = o.name(app)
+	if o.lowercaseApp {
+		app = strings.ToLower(app)
+	}
+	return app
+}
+
+// abs makes path absolute if configured by o. An empty path is returned unchanged.
+func (o *options) abs(path string) string {
+	if o.absolute && path != "" {
+		if abs, err := filepath.Abs(path); err == nil { // if NO error
+			path = abs
+		}
+	}
+	return path
+}
+
+// resolve applies the path transformations configured by o to an existing path.
+func (o *options) resolve(path string) string {
+	if o.resolveSymlinks {
+		if real, err := filepath.EvalSymlinks(path); err == nil { // if NO error
+			path = real
+		}
+	}
+	return path
+}
@@ -0,0 +1,979 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWithResolveSymlinks(t *testing.T) {
+	tmp := t.TempDir()
+	real := filepath.Join(tmp, "real")
+	if err := os.Mkdir(real, 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tmp, "link")
+	if err := os.Symlink(real, link); err != nil {
+		t.Skip(err)
+	}
+	broken := filepath.Join(tmp, "broken")
+	if err := os.Symlink(filepath.Join(tmp, "missing"), broken); err != nil {
+		t.Skip(err)
+	}
+	realTmp, err := filepath.EvalSymlinks(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Path     string
+		Opts     []Option
+		Expected string
+	}{
+		{link, nil, link},
+		{link, []Option{WithResolveSymlinks()}, filepath.Join(realTmp, "real")},
+		{broken, []Option{WithResolveSymlinks()}, broken},
+	}
+
+	for _, tc := range testCases {
+		if got := newOptions(tc.Opts).resolve(tc.Path); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestWithExpandEnv(t *testing.T) {
+	t.Setenv("DOTCONFIG_TEST_PROFILE", "work")
+
+	testCases := []struct {
+		Name     string
+		Opts     []Option
+		Expected string
+	}{
+		{"myapp-$DOTCONFIG_TEST_PROFILE", nil, "myapp-$DOTCONFIG_TEST_PROFILE"},
+		{"myapp-$DOTCONFIG_TEST_PROFILE", []Option{WithExpandEnv()}, "myapp-work"},
+		{"myapp-${DOTCONFIG_TEST_UNSET}", []Option{WithExpandEnv()}, "myapp-"},
+	}
+
+	for _, tc := range testCases {
+		if got := newOptions(tc.Opts).name(tc.Name); got != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, got)
+		}
+	}
+}
+
+func TestWithLowercaseApp(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	var probed []string
+	dirExists = func(dir string) bool {
+		probed = append(probed, dir)
+		return false
+	}
+	checkFile = func(path string) FileStatus {
+		probed = append(probed, path)
+		return NotExists
+	}
+	candidates := func(app string, opts ...Option) []string {
+		probed = nil
+		DirWith(app, opts...)
+		FileWith(app, "Config.yaml", opts...)
+		return probed
+	}
+
+	if lower, upper := candidates("myapp", WithLowercaseApp()), candidates("MyApp", WithLowercaseApp()); !slices.Equal(lower, upper) {
+		t.Errorf("Expected %v, got %v", lower, upper)
+	}
+	if lower, upper := candidates("myapp"), candidates("MyApp"); slices.Equal(lower, upper) {
+		t.Errorf("Expected the candidates to differ without the option, got %v", upper)
+	}
+
+	path, _ := FileWith("MyApp", "Config.yaml", WithLowercaseApp())
+	if path != "/mock/home/.config/myapp/Config.yaml" {
+		t.Errorf("Expected path to be '/mock/home/.config/myapp/Config.yaml', got '%s'", path)
+	}
+}
+
+func TestWithNoLocalFallback(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == ".myapp"
+	}
+	checkFile = func(path string) FileStatus {
+		if path == ".myapp/config.yaml" || path == ".myapp.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+
+	t.Run("home available", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DirWith("myapp", WithNoLocalFallback())
+		if dir != "/mock/home/.config/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+
+		path, status := FileWith("myapp", "config.yaml", WithNoLocalFallback())
+		if path != "/mock/home/.config/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.config/myapp/config.yaml', got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("home unavailable", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+		checkFile = CheckFile
+
+		dir, exist := DirWith("myapp", WithNoLocalFallback())
+		if dir != "" {
+			t.Errorf("Expected dir to be empty, got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+
+		path, status := FileWith("myapp", "config.yaml", WithNoLocalFallback())
+		if path != "" {
+			t.Errorf("Expected path to be empty, got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+
+	t.Run("combined with search order", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, _ := DirWith("myapp", WithSearchOrder([]Location{LocalDot, HomeDot}), WithNoLocalFallback())
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+	})
+}
+
+func TestWithHome(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/sandbox/home/.myapp"
+	}
+	checkFile = func(path string) FileStatus {
+		if path == "/sandbox/home/.myapp.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	dir, exist := DirWith("myapp", WithHome("/sandbox/home"))
+	if dir != "/sandbox/home/.myapp" {
+		t.Errorf("Expected dir to be '/sandbox/home/.myapp', got '%s'", dir)
+	}
+	if !exist {
+		t.Error("Expected exist to be true")
+	}
+
+	path, status := FileWith("myapp", "config.yaml", WithHome("/sandbox/home"))
+	if path != "/sandbox/home/.myapp.yaml" {
+		t.Errorf("Expected path to be '/sandbox/home/.myapp.yaml', got '%s'", path)
+	}
+	if status != FileExists {
+		t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+	}
+
+	dir, _ = DirWith("myapp")
+	if dir != ".myapp" {
+		t.Errorf("Expected dir without option to be '.myapp', got '%s'", dir)
+	}
+}
+
+func TestWithPlan9Compat(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+	origPlan9Lib := plan9Lib
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+		plan9Lib = origPlan9Lib
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/lib/myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	plan9Lib = false
+
+	dir, exist := DirWith("myapp")
+	if dir != "/mock/home/.config/myapp" {
+		t.Errorf("Expected dir without option to be '/mock/home/.config/myapp', got '%s'", dir)
+	}
+	if exist {
+		t.Error("Expected exist without option to be false")
+	}
+
+	dir, exist = DirWith("myapp", WithPlan9Compat())
+	if dir != "/mock/home/lib/myapp" {
+		t.Errorf("Expected dir to be '/mock/home/lib/myapp', got '%s'", dir)
+	}
+	if !exist {
+		t.Error("Expected exist to be true")
+	}
+
+	path, _ := FileWith("myapp", "config.yaml", WithPlan9Compat(), WithSearchOrder([]Location{Plan9Lib}))
+	if path != "/mock/home/lib/myapp/config.yaml" {
+		t.Errorf("Expected path to be '/mock/home/lib/myapp/config.yaml', got '%s'", path)
+	}
+}
+
+func TestWithoutPlan9(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/lib/myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	dir, exist := DirWith("myapp")
+	if dir != "/mock/home/lib/myapp" {
+		t.Errorf("Expected dir without option to be '/mock/home/lib/myapp', got '%s'", dir)
+	}
+	if !exist {
+		t.Error("Expected exist without option to be true")
+	}
+
+	dir, exist = DirWith("myapp", WithoutPlan9())
+	if dir != "/mock/home/.config/myapp" {
+		t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+	}
+	if exist {
+		t.Error("Expected exist to be false")
+	}
+
+	dir, _ = DirWith("myapp", WithoutPlan9(), WithPlan9Compat())
+	if dir != "/mock/home/lib/myapp" {
+		t.Errorf("Expected the last option to win, got '%s'", dir)
+	}
+
+	probed := []string{}
+	checkFile = func(path string) FileStatus {
+		probed = append(probed, path)
+		return NotExists
+	}
+	FileWith("myapp", "config.yaml", WithSearchOrder([]Location{Plan9Lib, HomeDot}), WithoutPlan9())
+	if slices.Contains(probed, "/mock/home/lib/myapp/config.yaml") {
+		t.Errorf("Expected '/mock/home/lib/myapp/config.yaml' not to be probed, got %v", probed)
+	}
+}
+
+func TestWithConfigBaseName(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return false
+	}
+	checkFile = func(path string) FileStatus {
+		if path == "/mock/home/.settings/myapp/config.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	dir, _ := DirWith("myapp", WithConfigBaseName(".settings"))
+	if dir != "/mock/home/.settings/myapp" {
+		t.Errorf("Expected dir to be '/mock/home/.settings/myapp', got '%s'", dir)
+	}
+
+	path, status := FileWith("myapp", "config.yaml", WithConfigBaseName(".settings"))
+	if path != "/mock/home/.settings/myapp/config.yaml" {
+		t.Errorf("Expected path to be '/mock/home/.settings/myapp/config.yaml', got '%s'", path)
+	}
+	if status != FileExists {
+		t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+	}
+
+	dir, _ = DirWith("myapp")
+	if dir != "/mock/home/.config/myapp" {
+		t.Errorf("Expected dir without option to be '/mock/home/.config/myapp', got '%s'", dir)
+	}
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dir, _ = DirWith("myapp", WithConfigBaseName(".settings"))
+	if dir != "/mock/xdg/myapp" {
+		t.Errorf("Expected dir with XDG_CONFIG_HOME to be '/mock/xdg/myapp', got '%s'", dir)
+	}
+}
+
+func TestWithEtc(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("order", func(t *testing.T) {
+		o := newOptions([]Option{WithEtc()})
+		expected := []Location{Snap, XDG, DotConfig, Plan9Lib, HomeDot, Etc, LocalDot}
+		if !slices.Equal(o.layout().order, expected) {
+			t.Errorf("Expected %v, got %v", expected, o.layout().order)
+		}
+
+		o = newOptions([]Option{WithSearchOrder([]Location{XDG}), WithEtc()})
+		expected = []Location{XDG, Etc}
+		if !slices.Equal(o.layout().order, expected) {
+			t.Errorf("Expected %v, got %v", expected, o.layout().order)
+		}
+	})
+
+	t.Run("dir found in etc", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/etc/myapp"
+		}
+
+		dir, exist := DirWith("myapp", WithEtc())
+		if dir != "/etc/myapp" {
+			t.Errorf("Expected dir to be '/etc/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+
+		dir, _ = DirWith("myapp")
+		if dir != "/mock/xdg/myapp" {
+			t.Errorf("Expected dir without option to be '/mock/xdg/myapp', got '%s'", dir)
+		}
+	})
+
+	t.Run("file found in etc", func(t *testing.T) {
+		probed := []string{}
+		checkFile = func(path string) FileStatus {
+			probed = append(probed, path)
+			if path == "/etc/myapp/config.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileWith("myapp", "config.yaml", WithEtc())
+		if path != "/etc/myapp/config.yaml" {
+			t.Errorf("Expected path to be '/etc/myapp/config.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+		// The search stops at the etc candidate, before the current-directory fallbacks.
+		if last := probed[len(probed)-1]; last != "/etc/myapp/config.yaml" {
+			t.Errorf("Expected the search to stop at '/etc/myapp/config.yaml', got '%s'", last)
+		}
+	})
+}
+
+func TestWithNamespace(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	dirExists = func(dir string) bool { return false }
+	checkFile = func(path string) FileStatus { return NotExists }
+
+	t.Run("file candidates", func(t *testing.T) {
+		o := newOptions([]Option{WithNamespace("acme")})
+		files := slices.Collect(newFileConfig("tool", "config.yaml").ListIn(o.layout()))
+
+		expected := []string{
+			"/mock/home/.config/acme/tool/config.yaml",
+			"/mock/home/lib/acme/tool/config.yaml",
+			"/mock/home/.acme/tool/config.yaml",
+			"/mock/home/.acme/tool.yaml",
+		}
+		if !slices.Equal(files, expected) {
+			t.Errorf("Expected %v, got %v", expected, files)
+		}
+	})
+
+	t.Run("file name from app", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.acme/tool/tool" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileWith("tool", ".", WithNamespace("acme"))
+		if path != "/mock/home/.acme/tool/tool" {
+			t.Errorf("Expected path to be '/mock/home/.acme/tool/tool', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("dir", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.acme/tool"
+		}
+
+		dir, exist := DirWith("tool", WithNamespace("acme"))
+		if dir != "/mock/home/.acme/tool" {
+			t.Errorf("Expected dir to be '/mock/home/.acme/tool', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("local fallback", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		dir, _ := DirWith("tool", WithNamespace("acme"))
+		if dir != filepath.Join(".acme", "tool") {
+			t.Errorf("Expected dir to be '.acme/tool', got '%s'", dir)
+		}
+	})
+
+	t.Run("empty namespace", func(t *testing.T) {
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+		dirExists = func(dir string) bool { return false }
+
+		dir, _ := DirWith("tool", WithNamespace(""))
+		if dir != "/mock/home/.config/tool" {
+			t.Errorf("Expected dir to be '/mock/home/.config/tool', got '%s'", dir)
+		}
+	})
+}
+
+func TestWithAbsolute(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+	dirExists = func(dir string) bool {
+		return dir == ".myapp"
+	}
+	checkFile = func(path string) FileStatus {
+		if path == ".myapp/config.yaml" {
+			return FileExists
+		}
+		return NotExists
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, exist := DirWith("myapp", WithAbsolute())
+	if expected := filepath.Join(wd, ".myapp"); dir != expected {
+		t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+	}
+	if !exist {
+		t.Error("Expected exist to be true")
+	}
+
+	path, status := FileWith("myapp", "config.yaml", WithAbsolute())
+	if expected := filepath.Join(wd, ".myapp", "config.yaml"); path != expected {
+		t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+	}
+	if status != FileExists {
+		t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+	}
+
+	dir, _ = DirWith("myapp")
+	if dir != ".myapp" {
+		t.Errorf("Expected dir without option to be '.myapp', got '%s'", dir)
+	}
+}
+
+func TestWithDeepestExistingAncestor(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	// XDG_CONFIG_HOME does not exist yet, while $HOME/.myapp does.
+	dirExists = func(dir string) bool {
+		switch dir {
+		case "/", "/mock", "/mock/home", "/mock/home/.myapp":
+			return true
+		}
+		return false
+	}
+	checkFile = func(path string) FileStatus {
+		if dirExists(path) {
+			return FileExists
+		}
+		if dirExists(filepath.Dir(path)) {
+			return BaseExists
+		}
+		return NotExists
+	}
+
+	path, status := FileWith("myapp", "config.yaml", WithDeepestExistingAncestor())
+	if path != "/mock/home/.myapp/config.yaml" {
+		t.Errorf("Expected path to be '/mock/home/.myapp/config.yaml', got '%s'", path)
+	}
+	if status != BaseExists {
+		t.Errorf("Expected status to be BaseExists (%d), got %d", BaseExists, status)
+	}
+
+	path, _ = FileWith("myapp", "config.yaml")
+	if path != "/mock/xdg/myapp/config.yaml" {
+		t.Errorf("Expected path without option to be '/mock/xdg/myapp/config.yaml', got '%s'", path)
+	}
+
+	// For directories, $HOME/.otherapp needs one directory to be created,
+	// while the XDG and $HOME/lib candidates need two.
+	dir, exist := DirWith("otherapp", WithDeepestExistingAncestor())
+	if dir != "/mock/home/.otherapp" {
+		t.Errorf("Expected dir to be '/mock/home/.otherapp', got '%s'", dir)
+	}
+	if exist {
+		t.Error("Expected exist to be false")
+	}
+
+	// With an existing $HOME/.config, the flat dot-file $HOME/.otherapp.yaml, whose directory
+	// always exists, must not win over $HOME/.config/otherapp/config.yaml.
+	xdgConfigHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		switch dir {
+		case "/", "/mock", "/mock/home", "/mock/home/.config":
+			return true
+		}
+		return false
+	}
+	path, status = FileWith("otherapp", "config.yaml", WithDeepestExistingAncestor())
+	if path != "/mock/home/.config/otherapp/config.yaml" {
+		t.Errorf("Expected path to be '/mock/home/.config/otherapp/config.yaml', got '%s'", path)
+	}
+	if status != NotExists {
+		t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+	}
+
+	// A candidate in the current directory is not compared with those under the home directory.
+	path, _ = FileWith("otherapp", "config.yaml", WithDeepestExistingAncestor(),
+		WithSearchOrder([]Location{DotConfig, LocalDot, HomeDot}))
+	if path != "/mock/home/.config/otherapp/config.yaml" {
+		t.Errorf("Expected path with LocalDot in order to be '/mock/home/.config/otherapp/config.yaml', got '%s'", path)
+	}
+}
+
+func TestWithCaseInsensitiveMatch(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	if err := os.Mkdir(filepath.Join(xdg, "MyApp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(xdg, "OtherApp"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		dir, exist := DirWith("myapp")
+
+		expected := filepath.Join(xdg, "myapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		dir, exist := DirWith("myapp", WithCaseInsensitiveMatch())
+
+		expected := filepath.Join(xdg, "MyApp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("regular file is not matched", func(t *testing.T) {
+		dir, exist := DirWith("otherapp", WithCaseInsensitiveMatch())
+
+		expected := filepath.Join(xdg, "otherapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+}
+
+func TestWithProbeLogger(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/xdg" || dir == "/mock/home/.myapp"
+	}
+	checkFile = func(path string) FileStatus {
+		switch path {
+		case "/mock/xdg/myapp/config.yaml":
+			return BaseExists
+		case "/mock/home/.myapp/config.yaml":
+			return FileExists
+		}
+		return NotExists
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	type probe struct {
+		Path   string
+		Status FileStatus
+	}
+	var probes []probe
+	logger := WithProbeLogger(func(path string, status FileStatus) {
+		probes = append(probes, probe{path, status})
+	})
+
+	dir, exist := DirWith("myapp", logger)
+	if dir != "/mock/home/.myapp" || !exist {
+		t.Errorf("Expected '/mock/home/.myapp', true, got '%s', %v", dir, exist)
+	}
+	expected := []probe{
+		{"/mock/xdg/myapp", BaseExists},
+		{"/mock/home/lib/myapp", NotExists},
+		{"/mock/home/.myapp", FileExists},
+	}
+	if !slices.Equal(probes, expected) {
+		t.Errorf("Expected %v, got %v", expected, probes)
+	}
+
+	probes = nil
+	path, status := FileWith("myapp", "config.yaml", logger)
+	if path != "/mock/home/.myapp/config.yaml" || status != FileExists {
+		t.Errorf("Expected '/mock/home/.myapp/config.yaml', FileExists, got '%s', %v", path, status)
+	}
+	expected = []probe{
+		{"/mock/xdg/myapp/config.yaml", BaseExists},
+		{"/mock/home/lib/myapp/config.yaml", NotExists},
+		{"/mock/home/.myapp/config.yaml", FileExists},
+	}
+	if !slices.Equal(probes, expected) {
+		t.Errorf("Expected %v, got %v", expected, probes)
+	}
+}
+
+func TestWithDotLocal(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("order", func(t *testing.T) {
+		o := newOptions([]Option{WithDotLocal()})
+		expected := []Location{Snap, XDG, DotConfig, Plan9Lib, DotLocal, HomeDot, LocalDot}
+		if !slices.Equal(o.layout().order, expected) {
+			t.Errorf("Expected %v, got %v", expected, o.layout().order)
+		}
+
+		o = newOptions([]Option{WithSearchOrder([]Location{XDG, LocalDot}), WithDotLocal(), WithEtc()})
+		expected = []Location{XDG, DotLocal, Etc, LocalDot}
+		if !slices.Equal(o.layout().order, expected) {
+			t.Errorf("Expected %v, got %v", expected, o.layout().order)
+		}
+	})
+
+	t.Run("dir found in .local", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.local/myapp" || dir == "/mock/home/.myapp"
+		}
+
+		dir, exist := DirWith("myapp", WithDotLocal())
+		if dir != "/mock/home/.local/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.local/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+
+		dir, _ = DirWith("myapp")
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir without option to be '/mock/home/.myapp', got '%s'", dir)
+		}
+	})
+
+	t.Run("file candidates", func(t *testing.T) {
+		probed := []string{}
+		checkFile = func(path string) FileStatus {
+			probed = append(probed, path)
+			return NotExists
+		}
+
+		FileWith("myapp", "config.yaml", WithDotLocal())
+		expected := []string{
+			"/mock/home/.config/myapp/config.yaml",
+			"/mock/home/lib/myapp/config.yaml",
+			"/mock/home/.local/myapp/config.yaml",
+			"/mock/home/.myapp/config.yaml",
+			"/mock/home/.myapp.yaml",
+		}
+		if !slices.Equal(probed[:len(expected)], expected) {
+			t.Errorf("Expected %v, got %v", expected, probed)
+		}
+	})
+}
+
+func TestWithDefaultExt(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("recommended path", func(t *testing.T) {
+		checkFile = func(path string) FileStatus { return NotExists }
+
+		testCases := []struct {
+			Name     string
+			Expected string
+		}{
+			{"config", "/mock/xdg/myapp/config.yaml"},
+			{"config.json", "/mock/xdg/myapp/config.json"},
+			{".", "/mock/xdg/myapp/myapp.yaml"},
+		}
+		for _, tc := range testCases {
+			if path, _ := FileWith("myapp", tc.Name, WithDefaultExt(".yaml")); path != tc.Expected {
+				t.Errorf("Expected path to be '%s', got '%s'", tc.Expected, path)
+			}
+		}
+	})
+
+	t.Run("home dot file exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileWith("myapp", "config", WithDefaultExt(".yaml"))
+		if path != "/mock/home/.myapp.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package dotconfig
+
+// Paths bundles the recommended configuration, data, cache, and state
+// directories of an application.
+type Paths struct {
+	// Config is the configuration directory, as returned by [Dir].
+	Config string
+
+	// Data is the data directory, as returned by [DataDir].
+	Data string
+
+	// Cache is the cache directory, as returned by [CacheDir].
+	Cache string
+
+	// State is the state directory, as returned by [StateDir].
+	State string
+}
+
+// NewPaths computes all directories of the specified application in one call.
+// Each path follows the same search order and fallback rules as the
+// corresponding individual function.
+//
+// Parameters:
+//   - app: The application name to search directories for
+//
+// Returns:
+//   - The directories of the application
+func NewPaths(app string) Paths {
+	var p Paths
+	p.Config, _ = Dir(app)
+	p.Data, _ = DataDir(app)
+	p.Cache, _ = CacheDir(app)
+	p.State, _ = StateDir(app)
+	return p
+}
+
+// Exist reports which of the directories exist on the filesystem.
+func (p Paths) Exist() (config, data, cache, state bool) {
+	return dirExists(p.Config), dirExists(p.Data), dirExists(p.Cache), dirExists(p.State)
+}
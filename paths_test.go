@@ -0,0 +1,59 @@
+package dotconfig
+
+import (
+	"testing"
+)
+
+func TestNewPaths(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origXdgDataHome := xdgDataHome
+	origXdgCacheHome := xdgCacheHome
+	origXdgStateHome := xdgStateHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		xdgDataHome = origXdgDataHome
+		xdgCacheHome = origXdgCacheHome
+		xdgStateHome = origXdgStateHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	xdgDataHome = func() string { return "" }
+	xdgCacheHome = func() string { return "/mock/cache" }
+	xdgStateHome = func() string { return "" }
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/.config/myapp" || dir == "/mock/home/.myapp"
+	}
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	p := NewPaths("myapp")
+
+	expected := Paths{
+		Config: "/mock/home/.config/myapp",
+		Data:   "/mock/home/.myapp",
+		Cache:  "/mock/home/.myapp",
+		State:  "/mock/home/.myapp",
+	}
+	if p != expected {
+		t.Errorf("Expected %+v, got %+v", expected, p)
+	}
+
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/.config/myapp"
+	}
+	config, data, cache, state := p.Exist()
+	if !config {
+		t.Error("Expected config to be true")
+	}
+	if data || cache || state {
+		t.Errorf("Expected data, cache, and state to be false, got %v, %v, %v", data, cache, state)
+	}
+}
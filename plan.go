@@ -0,0 +1,62 @@
+package dotconfig
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// PlanCreate reports, without touching the filesystem, what [EnsureDir] would create
+// for the specified application, so that a caller can ask for confirmation first.
+//
+// The target is the directory located by [Dir]. If it already exists, toCreate is empty.
+// Otherwise toCreate lists the target and its missing ancestors in creation order,
+// from the outermost missing ancestor to the target itself.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - target: The configuration directory path
+//   - toCreate: The directories that would be created, in creation order
+func PlanCreate(app string) (target string, toCreate []string) {
+	target, exist := Dir(app)
+	if exist {
+		return target, nil
+	}
+	return target, missingDirs(target)
+}
+
+// FilePlanCreate is like [PlanCreate] but for the configuration file located by [File].
+//
+// If the file already exists, toCreate is empty. Otherwise toCreate lists the missing
+// directories of the parent chain of the file in creation order, followed by the file itself.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file
+//
+// Returns:
+//   - target: The configuration file path
+//   - toCreate: The directories and the file that would be created, in creation order
+func FilePlanCreate(app, name string) (target string, toCreate []string) {
+	target, status := File(app, name)
+	if status == FileExists {
+		return target, nil
+	}
+	return target, append(missingDirs(filepath.Dir(target)), target)
+}
+
+// missingDirs returns dir and its ancestors that do not exist, from the outermost one to dir.
+func missingDirs(dir string) []string {
+	var dirs []string
+	for !dirExists(dir) {
+		dirs = append(dirs, dir)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	slices.Reverse(dirs)
+	return dirs
+}
@@ -0,0 +1,6 @@
+//go:build plan9
+
+package dotconfig
+
+// plan9Compat reports whether the [Plan9Lib] location is searched by default.
+const plan9Compat = true
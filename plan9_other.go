@@ -0,0 +1,7 @@
+//go:build !plan9
+
+package dotconfig
+
+// plan9Compat reports whether the [Plan9Lib] location is searched by default.
+// $HOME/lib is unconventional outside Plan9 and may hold unrelated directories.
+const plan9Compat = false
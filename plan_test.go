@@ -0,0 +1,116 @@
+package dotconfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlanCreate(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("directory exists", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.config/myapp"
+		}
+
+		target, toCreate := PlanCreate("myapp")
+
+		if target != "/mock/home/.config/myapp" {
+			t.Errorf("Expected target to be '/mock/home/.config/myapp', got '%s'", target)
+		}
+		if len(toCreate) != 0 {
+			t.Errorf("Expected nothing to create, got %v", toCreate)
+		}
+	})
+
+	t.Run("ancestors missing", func(t *testing.T) {
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home" || dir == "/mock" || dir == "/"
+		}
+
+		target, toCreate := PlanCreate("acme/tool")
+
+		if target != "/mock/home/.config/acme/tool" {
+			t.Errorf("Expected target to be '/mock/home/.config/acme/tool', got '%s'", target)
+		}
+		expected := []string{"/mock/home/.config", "/mock/home/.config/acme", "/mock/home/.config/acme/tool"}
+		if !slices.Equal(toCreate, expected) {
+			t.Errorf("Expected %v, got %v", expected, toCreate)
+		}
+	})
+}
+
+func TestFilePlanCreate(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+	dirExists = func(dir string) bool {
+		return dir == "/mock/home/.config" || dir == "/mock/home" || dir == "/mock" || dir == "/"
+	}
+
+	t.Run("file exists", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		target, toCreate := FilePlanCreate("myapp", "config.yaml")
+
+		if target != "/mock/home/.myapp.yaml" {
+			t.Errorf("Expected target to be '/mock/home/.myapp.yaml', got '%s'", target)
+		}
+		if len(toCreate) != 0 {
+			t.Errorf("Expected nothing to create, got %v", toCreate)
+		}
+	})
+
+	t.Run("parent missing", func(t *testing.T) {
+		checkFile = func(path string) FileStatus {
+			return NotExists
+		}
+
+		target, toCreate := FilePlanCreate("myapp", "config.yaml")
+
+		if target != "/mock/home/.config/myapp/config.yaml" {
+			t.Errorf("Expected target to be '/mock/home/.config/myapp/config.yaml', got '%s'", target)
+		}
+		expected := []string{"/mock/home/.config/myapp", "/mock/home/.config/myapp/config.yaml"}
+		if !slices.Equal(toCreate, expected) {
+			t.Errorf("Expected %v, got %v", expected, toCreate)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package dotconfig
+
+import (
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+// PortableDir searches for a configuration directory stored next to the executable,
+// as used by portable applications, before falling back to [Dir].
+//
+// The function tries the following locations in order:
+//
+//  1. <exe>/<app> (where <exe> is the directory of [os.Executable])
+//  2. <exe>/config
+//  3. The locations searched by [Dir]
+//
+// If [os.Executable] returns an error, or app is not valid according to [ValidateApp],
+// the portable locations are skipped.
+// If no portable directory exists, it returns the result of [Dir].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func PortableDir(app string) (dir string, exist bool) {
+	for dir := range listPortable(app) {
+		if dirExists(dir) {
+			return dir, true
+		}
+	}
+	return Dir(app)
+}
+
+// listPortable yields the portable candidates of app, or nothing if app is not valid
+// according to [ValidateApp], so that a name such as ".." cannot escape the executable's directory.
+func listPortable(app string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if ValidateApp(normalizeApp(app)) != nil {
+			return
+		}
+		if exe, err := executable(); err == nil { // if NO error
+			base := filepath.Dir(exe)
+			if yield(filepath.Join(base, normalizeApp(app))) {
+				yield(filepath.Join(base, "config"))
+			}
+		}
+	}
+}
+
+var executable = os.Executable
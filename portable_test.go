@@ -0,0 +1,104 @@
+package dotconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPortableDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+	origExecutable := executable
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+		executable = origExecutable
+	}()
+
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("app dir beside executable exists", func(t *testing.T) {
+		// Mock functions
+		executable = func() (string, error) { return "/mock/usb/bin/myapp", nil }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/usb/bin/myapp" || dir == "/mock/home/.config/myapp"
+		}
+
+		dir, exist := PortableDir("myapp")
+
+		if dir != "/mock/usb/bin/myapp" {
+			t.Errorf("Expected dir to be '/mock/usb/bin/myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("config dir beside executable exists", func(t *testing.T) {
+		// Mock functions
+		executable = func() (string, error) { return "/mock/usb/bin/myapp", nil }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/usb/bin/config"
+		}
+
+		dir, exist := PortableDir("myapp")
+
+		if dir != "/mock/usb/bin/config" {
+			t.Errorf("Expected dir to be '/mock/usb/bin/config', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No portable dir, fallback to Dir", func(t *testing.T) {
+		// Mock functions
+		executable = func() (string, error) { return "/mock/usb/bin/myapp", nil }
+		dirExists = func(dir string) bool { return false }
+
+		dir, exist := PortableDir("myapp")
+
+		if dir != "/mock/home/.config/myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.config/myapp', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("Executable error skips portable candidates", func(t *testing.T) {
+		// Mock functions
+		executable = func() (string, error) { return "", errors.New("mock error") }
+		dirExists = func(dir string) bool {
+			return dir == "myapp" || dir == "config" || dir == "/mock/home/.myapp"
+		}
+
+		dir, exist := PortableDir("myapp")
+
+		if dir != "/mock/home/.myapp" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+	t.Run("invalid app skips portable candidates", func(t *testing.T) {
+		// Mock functions
+		executable = func() (string, error) { return "/mock/usb/bin/myapp", nil }
+		dirExists = func(dir string) bool { return true }
+
+		for _, app := range []string{"..", ""} {
+			if dir, exist := PortableDir(app); dir != "" || exist {
+				t.Errorf("PortableDir(%q): expected \"\" and false, got '%s' and %v", app, dir, exist)
+			}
+		}
+	})
+}
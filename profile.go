@@ -0,0 +1,76 @@
+package dotconfig
+
+import (
+	"context"
+	"iter"
+	"path/filepath"
+)
+
+// DirProfile searches for the configuration directory of a profile of the specified application.
+// The profile is inserted as a subdirectory of every location searched by [Dir],
+// such as $XDG_CONFIG_HOME/<app>/<profile> and $HOME/.<app>/<profile>.
+// The current-directory fallback becomes .<app>/<profile>.
+//
+// If profile is empty, it behaves identically to [Dir]. If profile has an empty, "." or ".." segment,
+// which [ValidateApp] rejects in application names too, nothing is searched and it returns "" and false.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - profile: The name of the profile
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - exist: Boolean indicating whether the directory exists on the filesystem
+func DirProfile(app, profile string) (dir string, exist bool) {
+	if !validProfile(profile) {
+		return "", false
+	}
+	local := localDir(app)
+	if local != "" {
+		local = filepath.Join(local, profile)
+	}
+	dir, status := searchDir(listProfile(list(app), profile), local)
+	return dir, status == FileExists
+}
+
+// FileProfile searches for a configuration file of a profile of the specified application.
+// The profile is inserted as a subdirectory of every directory location searched by [File],
+// such as $XDG_CONFIG_HOME/<app>/<profile>/<name>, and the dot-prefixed file fallbacks
+// become .<app>-<profile><ext>.
+//
+// If profile is empty, it behaves identically to [File]. If profile is not valid as for [DirProfile],
+// nothing is searched and it returns "" and [NotExists].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - profile: The name of the profile
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - path: The configuration file path
+//   - status: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func FileProfile(app, profile, name string) (path string, status FileStatus) {
+	if !validProfile(profile) {
+		return "", NotExists
+	}
+	cfg := newFileConfig(app, name)
+	cfg.Profile = profile
+	path, status, _ = cfg.search(context.Background(), configLayout(), checkFile)
+	return path, status
+}
+
+// validProfile reports whether profile is empty or follows the segment rules of [ValidateApp],
+// so that a profile such as "../.." cannot escape the application directory.
+func validProfile(profile string) bool {
+	return profile == "" || ValidateApp(profile) == nil
+}
+
+func listProfile(dirs iter.Seq[string], profile string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for dir := range dirs {
+			if !yield(filepath.Join(dir, profile)) {
+				return
+			}
+		}
+	}
+}
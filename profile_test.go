@@ -0,0 +1,212 @@
+package dotconfig
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDirProfile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		userHomeDir = origUserHomeDir
+	}()
+
+	t.Run("home dot dir profile exists", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/xdg/myapp" || dir == "/mock/home/.myapp/work"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DirProfile("myapp", "work")
+
+		if dir != "/mock/home/.myapp/work" {
+			t.Errorf("Expected dir to be '/mock/home/.myapp/work', got '%s'", dir)
+		}
+		if !exist {
+			t.Error("Expected exist to be true")
+		}
+	})
+
+	t.Run("No directories exist, fallback to first option", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DirProfile("myapp", "work")
+
+		if dir != "/mock/home/.config/myapp/work" {
+			t.Errorf("Expected dir to be '/mock/home/.config/myapp/work', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("No locations available, use local dir", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool { return false }
+		userHomeDir = func() (string, error) {
+			return "", os.ErrNotExist
+		}
+
+		dir, exist := DirProfile("myapp", "work")
+
+		if dir != ".myapp/work" {
+			t.Errorf("Expected dir to be '.myapp/work', got '%s'", dir)
+		}
+		if exist {
+			t.Error("Expected exist to be false")
+		}
+	})
+
+	t.Run("Empty profile behaves like Dir", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "/mock/xdg" }
+		dirExists = func(dir string) bool {
+			return dir == "/mock/home/.myapp"
+		}
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		dir, exist := DirProfile("myapp", "")
+		expectedDir, expectedExist := Dir("myapp")
+
+		if dir != expectedDir {
+			t.Errorf("Expected dir to be '%s', got '%s'", expectedDir, dir)
+		}
+		if exist != expectedExist {
+			t.Errorf("Expected exist to be %v, got %v", expectedExist, exist)
+		}
+	})
+
+	t.Run("Invalid profile is rejected", func(t *testing.T) {
+		// Mock functions
+		xdgConfigHome = func() string { return "" }
+		dirExists = func(dir string) bool { return true }
+		userHomeDir = func() (string, error) {
+			return "/mock/home", nil
+		}
+
+		for _, profile := range []string{"../..", "..", "work/../.."} {
+			if dir, exist := DirProfile("myapp", profile); dir != "" || exist {
+				t.Errorf("DirProfile(%q): expected \"\" and false, got '%s' and %v", profile, dir, exist)
+			}
+		}
+	})
+}
+
+func TestFileProfile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	t.Run("candidates", func(t *testing.T) {
+		cfg := newFileConfig("myapp", "config.yaml")
+		cfg.Profile = "work"
+		paths := []string{}
+		for path := range cfg.List() {
+			paths = append(paths, path)
+		}
+
+		expectedPaths := []string{
+			"/mock/xdg/myapp/work/config.yaml",
+			"/mock/home/lib/myapp/work/config.yaml",
+			"/mock/home/.myapp/work/config.yaml",
+			"/mock/home/.myapp-work.yaml",
+		}
+
+		if len(paths) != len(expectedPaths) {
+			t.Errorf("Expected %d paths, got %d", len(expectedPaths), len(paths))
+		}
+
+		for i, expected := range expectedPaths {
+			if i >= len(paths) {
+				t.Errorf("Missing expected path at index %d: %s", i, expected)
+				continue
+			}
+			if paths[i] != expected {
+				t.Errorf("Expected path[%d] to be '%s', got '%s'", i, expected, paths[i])
+			}
+		}
+	})
+
+	t.Run("dot file exists", func(t *testing.T) {
+		origCheckFile := checkFile
+		defer func() { checkFile = origCheckFile }()
+		checkFile = func(path string) FileStatus {
+			if path == "/mock/home/.myapp-work.yaml" {
+				return FileExists
+			}
+			return NotExists
+		}
+
+		path, status := FileProfile("myapp", "work", "config.yaml")
+
+		if path != "/mock/home/.myapp-work.yaml" {
+			t.Errorf("Expected path to be '/mock/home/.myapp-work.yaml', got '%s'", path)
+		}
+		if status != FileExists {
+			t.Errorf("Expected status to be FileExists (%d), got %d", FileExists, status)
+		}
+	})
+
+	t.Run("Empty profile behaves like File", func(t *testing.T) {
+		profile := []string{}
+		cfg := newFileConfig("myapp", "config.yaml")
+		cfg.Profile = ""
+		for path := range cfg.List() {
+			profile = append(profile, path)
+		}
+		plain := []string{}
+		for path := range ListFiles("myapp", "config.yaml") {
+			plain = append(plain, path)
+		}
+
+		if len(profile) != len(plain) {
+			t.Fatalf("Expected %d paths, got %d", len(plain), len(profile))
+		}
+		for i := range plain {
+			if profile[i] != plain[i] {
+				t.Errorf("Expected path[%d] to be '%s', got '%s'", i, plain[i], profile[i])
+			}
+		}
+	})
+
+	t.Run("Invalid profile is rejected", func(t *testing.T) {
+		path, status := FileProfile("myapp", "../..", "config.yaml")
+
+		if path != "" {
+			t.Errorf("Expected path to be empty, got '%s'", path)
+		}
+		if status != NotExists {
+			t.Errorf("Expected status to be NotExists (%d), got %d", NotExists, status)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package dotconfig
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+)
+
+const (
+	// stdinName is the file name that means the standard input, as is customary for command-line tools.
+	stdinName = "-"
+
+	// stdinPath is the path reported for data read from the standard input.
+	stdinPath = "<stdin>"
+)
+
+var stdin io.Reader = os.Stdin
+
+// ReadFile reads the configuration file for the specified application.
+// It resolves the path using [File] and reads the file only when it exists.
+//
+// If the file does not exist, it returns an [*fs.PathError] wrapping [fs.ErrNotExist]
+// with the path that would have been read.
+//
+// If name is "-", it reads the standard input instead, without searching the filesystem,
+// and returns the path "<stdin>".
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to read
+//
+// Returns:
+//   - data: The contents of the configuration file
+//   - path: The configuration file path
+//   - err: An error if the file does not exist or could not be read
+func ReadFile(app, name string) (data []byte, path string, err error) {
+	if name == stdinName {
+		data, err = io.ReadAll(stdin)
+		return data, stdinPath, err
+	}
+	path, status := File(app, name)
+	if status != FileExists {
+		return nil, path, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	data, err = os.ReadFile(path)
+	return data, path, err
+}
+
+// ReadConfig is like [ReadFile] but treats a missing configuration file as an empty configuration:
+// if the file does not exist, it returns nil data, the path where the file would be, and a nil error.
+// This suits applications whose configuration file is optional.
+//
+// As with [ReadFile], if name is "-", it reads the standard input instead and returns the path "<stdin>".
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to read, or "-" for the standard input
+//
+// Returns:
+//   - data: The contents of the configuration file, or nil if it does not exist
+//   - path: The configuration file path
+//   - err: An error if the file could not be read
+func ReadConfig(app, name string) (data []byte, path string, err error) {
+	data, path, err = ReadFile(app, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, path, nil
+	}
+	return data, path, err
+}
+
+// FileReadFunc reads each existing configuration file for the specified application
+// in the search order of [File] and calls fn with its path and contents.
+//
+// If fn returns nil, FileReadFunc stops and returns nil. If fn returns an error,
+// or the file could not be read, it moves on to the next existing file.
+// When every existing file fails, it returns the last error.
+// When no file exists, it returns an [*fs.PathError] wrapping [fs.ErrNotExist]
+// with the path that [File] would return.
+//
+// This allows falling back to a lower-priority configuration when a higher-priority one is malformed.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to read
+//   - fn: The function that parses the contents of a configuration file
+//
+// Returns:
+//   - err: nil if fn accepted a file, otherwise the last error
+func FileReadFunc(app, name string, fn func(path string, data []byte) error) error {
+	var fallback string
+	var lastErr error
+	for file := range ListFiles(app, name) {
+		if fallback == "" {
+			fallback = file
+		}
+		if checkFile(file) != FileExists {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err == nil {
+			err = fn(file, data)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return &fs.PathError{Op: "open", Path: fallback, Err: fs.ErrNotExist}
+}
+
+// MergeRead reads every existing configuration file for the specified application, as found by [FileAll],
+// in reverse precedence: the lowest-precedence file first and the highest-precedence file last.
+// Applying the contents in order thus lets each file override the ones before it.
+//
+// Files that cannot be read are skipped, and their errors are combined with [errors.Join]
+// into err, so that a partial failure is visible while the readable files are still returned.
+// If no file exists, it returns no contents and a nil error.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to read
+//
+// Returns:
+//   - contents: The contents of each readable configuration file
+//   - paths: The path of each file in contents, at the same index
+//   - err: The combined errors of the files that could not be read, or nil
+func MergeRead(app, name string) (contents [][]byte, paths []string, err error) {
+	var errs []error
+	for _, path := range slices.Backward(FileAll(app, name)) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		contents = append(contents, data)
+		paths = append(paths, path)
+	}
+	return contents, paths, errors.Join(errs...)
+}
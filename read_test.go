@@ -0,0 +1,309 @@
+package dotconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("file not exists", func(t *testing.T) {
+		data, path, err := ReadFile("myapp", "config.yaml")
+
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if data != nil {
+			t.Errorf("Expected data to be nil, got '%s'", data)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected fs.ErrNotExist, got %v", err)
+		}
+		var pathErr *fs.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != expected {
+			t.Errorf("Expected error to carry path '%s', got %v", expected, err)
+		}
+	})
+
+	t.Run("file exists", func(t *testing.T) {
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		if err := os.MkdirAll(filepath.Dir(expected), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(expected, []byte("key: value\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, path, err := ReadFile("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if string(data) != "key: value\n" {
+			t.Errorf("Expected data to be 'key: value\\n', got '%s'", data)
+		}
+	})
+}
+
+func TestFileReadFunc(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	home := filepath.Join(tmp, "home")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+	errMalformed := errors.New("malformed")
+	parse := func(path string, data []byte) error {
+		if string(data) != "ok" {
+			return errMalformed
+		}
+		return nil
+	}
+
+	t.Run("no file exists", func(t *testing.T) {
+		err := FileReadFunc("myapp", "config.yaml", parse)
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected fs.ErrNotExist, got %v", err)
+		}
+	})
+
+	user := filepath.Join(xdg, "myapp", "config.yaml")
+	system := filepath.Join(home, ".myapp.yaml")
+	for _, file := range []string{user, system} {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(user, []byte("broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all files fail", func(t *testing.T) {
+		err := FileReadFunc("myapp", "config.yaml", parse)
+
+		if !errors.Is(err, errMalformed) {
+			t.Errorf("Expected errMalformed, got %v", err)
+		}
+	})
+
+	if err := os.WriteFile(system, []byte("ok"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("fall back to next valid file", func(t *testing.T) {
+		paths := []string{}
+		err := FileReadFunc("myapp", "config.yaml", func(path string, data []byte) error {
+			paths = append(paths, path)
+			return parse(path, data)
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(paths) != 2 || paths[0] != user || paths[1] != system {
+			t.Errorf("Expected [%s %s], got %v", user, system, paths)
+		}
+	})
+}
+
+func TestMergeRead(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	home := filepath.Join(tmp, "home")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	t.Run("no file exists", func(t *testing.T) {
+		contents, paths, err := MergeRead("myapp", "config.yaml")
+
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if len(contents) != 0 || len(paths) != 0 {
+			t.Errorf("Expected no files, got %v", paths)
+		}
+	})
+
+	user := filepath.Join(xdg, "myapp", "config.yaml")
+	system := filepath.Join(home, ".myapp.yaml")
+	for file, data := range map[string]string{user: "user", system: "system"} {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("reverse precedence", func(t *testing.T) {
+		contents, paths, err := MergeRead("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(paths) != 2 || paths[0] != system || paths[1] != user {
+			t.Errorf("Expected [%s %s], got %v", system, user, paths)
+		}
+		if len(contents) != 2 || string(contents[0]) != "system" || string(contents[1]) != "user" {
+			t.Errorf("Expected [system user], got %q", contents)
+		}
+	})
+
+	// A directory in place of a file exists but cannot be read.
+	broken := filepath.Join(home, ".myapp", "config.yaml")
+	if err := os.MkdirAll(broken, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unreadable file", func(t *testing.T) {
+		contents, paths, err := MergeRead("myapp", "config.yaml")
+
+		if err == nil {
+			t.Error("Expected an error for the unreadable file")
+		}
+		if len(paths) != 2 || paths[0] != system || paths[1] != user {
+			t.Errorf("Expected [%s %s], got %v", system, user, paths)
+		}
+		if len(contents) != 2 {
+			t.Errorf("Expected 2 contents, got %d", len(contents))
+		}
+	})
+}
+
+func TestReadFileStdin(t *testing.T) {
+	// Save original functions to restore later
+	origStdin := stdin
+	origXdgConfigHome := xdgConfigHome
+	origCheckFile := checkFile
+
+	// Restore original functions after test
+	defer func() {
+		stdin = origStdin
+		xdgConfigHome = origXdgConfigHome
+		checkFile = origCheckFile
+	}()
+
+	// Mock functions
+	xdgConfigHome = func() string {
+		t.Error("Expected no search for the standard input")
+		return ""
+	}
+	checkFile = func(path string) FileStatus {
+		t.Errorf("Expected no search for the standard input, got checkFile(%q)", path)
+		return NotExists
+	}
+
+	for _, read := range []func(app, name string) ([]byte, string, error){ReadFile, ReadConfig} {
+		stdin = strings.NewReader("key: value\n")
+
+		data, path, err := read("myapp", "-")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != "<stdin>" {
+			t.Errorf("Expected path to be '<stdin>', got '%s'", path)
+		}
+		if string(data) != "key: value\n" {
+			t.Errorf("Expected data to be 'key: value\\n', got '%s'", data)
+		}
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+	expected := filepath.Join(xdg, "myapp", "config.yaml")
+
+	t.Run("file not exists", func(t *testing.T) {
+		data, path, err := ReadConfig("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if data != nil {
+			t.Errorf("Expected data to be nil, got '%s'", data)
+		}
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+	})
+
+	t.Run("file exists", func(t *testing.T) {
+		if err := os.MkdirAll(filepath.Dir(expected), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(expected, []byte("key: value\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, _, err := ReadConfig("myapp", "config.yaml")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if string(data) != "key: value\n" {
+			t.Errorf("Expected data to be 'key: value\\n', got '%s'", data)
+		}
+	})
+}
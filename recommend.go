@@ -0,0 +1,43 @@
+package dotconfig
+
+// RecommendDir returns the path where the configuration directory of the specified application
+// should be, without accessing the filesystem: the first candidate of [ListDirs], or ".<app>" when
+// no candidate could be determined. This is the path [Dir] returns when no directory exists.
+//
+// Only XDG_CONFIG_HOME and the home directory are resolved; no existence check is made.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - The recommended configuration directory path
+func RecommendDir(app string) string {
+	for dir := range list(app) {
+		return dir
+	}
+	return localDir(app)
+}
+
+// RecommendFile returns the path where the configuration file of the specified application
+// should be, without accessing the filesystem: the first candidate of [ListFiles], or ".<app><ext>"
+// when only the current-directory fallbacks could be determined.
+// This is the path [File] returns when no file exists.
+//
+// Only XDG_CONFIG_HOME and the home directory are resolved; no existence check is made.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file
+//
+// Returns:
+//   - The recommended configuration file path
+func RecommendFile(app, name string) string {
+	candidates, local := newFileConfig(app, name).files(configLayout())
+	for _, file := range candidates {
+		return file
+	}
+	if len(local) > 0 {
+		return local[len(local)-1]
+	}
+	return ""
+}
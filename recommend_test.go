@@ -0,0 +1,60 @@
+package dotconfig
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	dirExists = func(dir string) bool {
+		t.Errorf("Expected no existence check, got dirExists(%q)", dir)
+		return false
+	}
+	checkFile = func(path string) FileStatus {
+		t.Errorf("Expected no existence check, got checkFile(%q)", path)
+		return NotExists
+	}
+
+	testCases := []struct {
+		Name         string
+		XDG          string
+		HomeErr      error
+		ExpectedDir  string
+		ExpectedFile string
+	}{
+		{"XDG", "/mock/xdg", nil, "/mock/xdg/myapp", "/mock/xdg/myapp/config.yaml"},
+		{"home", "", nil, "/mock/home/.config/myapp", "/mock/home/.config/myapp/config.yaml"},
+		// Like File, the dot-file is recommended among the current-directory fallbacks.
+		{"no locations", "", os.ErrNotExist, ".myapp", ".myapp.yaml"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			xdgConfigHome = func() string { return tc.XDG }
+			userHomeDir = func() (string, error) {
+				return "/mock/home", tc.HomeErr
+			}
+
+			if dir := RecommendDir("myapp"); dir != tc.ExpectedDir {
+				t.Errorf("Expected dir to be '%s', got '%s'", tc.ExpectedDir, dir)
+			}
+			if path := RecommendFile("myapp", "config.yaml"); path != tc.ExpectedFile {
+				t.Errorf("Expected path to be '%s', got '%s'", tc.ExpectedFile, path)
+			}
+		})
+	}
+}
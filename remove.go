@@ -0,0 +1,91 @@
+package dotconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsafeRemove is returned by [RemoveDir] when a directory is too dangerous to remove,
+// such as the root or home directory.
+var ErrUnsafeRemove = errors.New("dotconfig: refusing to remove unsafe path")
+
+// RemoveDir removes every existing configuration directory of the specified application,
+// as found by [DirAll], with [os.RemoveAll], for uninstalling the configuration of an application.
+//
+// The application name is validated with [ValidateApp] first. Before anything is removed,
+// every directory is checked, and if any of them is empty, a root directory, the current directory,
+// or contains the base directory of another location, such as the home directory, $HOME/.config,
+// $HOME/.local or XDG_CONFIG_HOME, nothing is removed and an error wrapping [ErrUnsafeRemove] is returned.
+// This keeps, for example, RemoveDir("config") from removing $HOME/.config with every other application in it.
+//
+// Parameters:
+//   - app: The application name whose configuration is removed
+//
+// Returns:
+//   - removed: The directories actually removed
+//   - err: An error if the name or a directory is unsafe, or a directory could not be removed
+func RemoveDir(app string) (removed []string, err error) {
+	if err := ValidateApp(app); err != nil {
+		return nil, err
+	}
+	dirs := DirAll(app)
+	for _, dir := range dirs {
+		if unsafeRemove(dir) {
+			return nil, fmt.Errorf("%w: %q", ErrUnsafeRemove, dir)
+		}
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return removed, err
+		}
+		removed = append(removed, dir)
+	}
+	return removed, nil
+}
+
+// unsafeRemove reports whether removing dir recursively could destroy more than a configuration.
+func unsafeRemove(dir string) bool {
+	if dir == "" {
+		return true
+	}
+	dir = filepath.Clean(dir)
+	if dir == "." || filepath.Dir(dir) == dir {
+		return true
+	}
+	for _, base := range protectedBases() {
+		if base != "" && contains(dir, filepath.Clean(base)) {
+			return true
+		}
+	}
+	return false
+}
+
+// protectedBases returns the base directories shared by every application,
+// which a configuration directory must never contain. Undetermined ones are "".
+func protectedBases() []string {
+	bases := []string{xdgConfigHome(), xdgDataHome(), xdgCacheHome(), xdgStateHome(), snapUserCommon()}
+	if home, err := userHomeDir(); err == nil {
+		bases = append(bases,
+			home,
+			filepath.Join(home, ".config"),
+			filepath.Join(home, ".local"),
+			filepath.Join(home, ".local", "share"),
+			filepath.Join(home, ".local", "state"),
+			filepath.Join(home, ".cache"),
+			filepath.Join(home, "lib"),
+		)
+	}
+	return bases
+}
+
+// contains reports whether path is dir or inside it. Both must be clean.
+func contains(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
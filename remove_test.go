@@ -0,0 +1,141 @@
+package dotconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestRemoveDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	home := filepath.Join(tmp, "home")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return home, nil
+	}
+
+	t.Run("remove existing directories", func(t *testing.T) {
+		dirs := []string{filepath.Join(xdg, "myapp"), filepath.Join(home, ".myapp")}
+		for _, dir := range dirs {
+			if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		other := filepath.Join(xdg, "otherapp")
+		if err := os.MkdirAll(other, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		removed, err := RemoveDir("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(removed, dirs) {
+			t.Errorf("Expected %v, got %v", dirs, removed)
+		}
+		for _, dir := range dirs {
+			if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected '%s' to be removed, got %v", dir, err)
+			}
+		}
+		if !DirExists(other) {
+			t.Error("Expected other application to be kept")
+		}
+	})
+
+	t.Run("nothing to remove", func(t *testing.T) {
+		removed, err := RemoveDir("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("Expected nothing removed, got %v", removed)
+		}
+	})
+
+	t.Run("default config base", func(t *testing.T) {
+		// Without XDG_CONFIG_HOME, $HOME/.config is the $HOME/.<app> candidate of "config".
+		xdgConfigHome = func() string { return "" }
+		defer func() { xdgConfigHome = func() string { return xdg } }()
+		other := filepath.Join(home, ".config", "otherapp")
+		if err := os.MkdirAll(other, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		removed, err := RemoveDir("config")
+
+		if !errors.Is(err, ErrUnsafeRemove) {
+			t.Errorf("Expected ErrUnsafeRemove, got %v", err)
+		}
+		if len(removed) != 0 {
+			t.Errorf("Expected nothing removed, got %v", removed)
+		}
+		if !DirExists(other) {
+			t.Error("Expected other application to be kept")
+		}
+	})
+
+	t.Run("invalid app", func(t *testing.T) {
+		if _, err := RemoveDir(""); !errors.Is(err, ErrInvalidApp) {
+			t.Errorf("Expected ErrInvalidApp, got %v", err)
+		}
+	})
+}
+
+func TestUnsafeRemove(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdgConfigHome = func() string { return "/mock/xdg" }
+	userHomeDir = func() (string, error) {
+		return "/mock/home", nil
+	}
+
+	testCases := []struct {
+		Dir      string
+		Expected bool
+	}{
+		{"", true},
+		{"/", true},
+		{".", true},
+		{"/mock/home", true},
+		{"/mock/home/", true},
+		{"/mock/xdg", true},
+		{"/mock", true},
+		{"/mock/home/.config", true},
+		{"/mock/home/.local", true},
+		{"/mock/home/.local/share", true},
+		{"/mock/home/.local/state", true},
+		{"/mock/home/.cache", true},
+		{"/mock/xdg/myapp", false},
+		{"/mock/home/.myapp", false},
+		{".myapp", false},
+	}
+	for _, tc := range testCases {
+		if got := unsafeRemove(tc.Dir); got != tc.Expected {
+			t.Errorf("Expected %v for %q, got %v", tc.Expected, tc.Dir, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package dotconfig
+
+import (
+	"context"
+	"path/filepath"
+)
+
+// Resolve locates both the configuration directory, like [Dir], and a configuration file, like [File],
+// in a single call that determines the home directory only once.
+//
+// The file is kept consistent with the directory when possible: if no existing file is found
+// but an existing directory is, the file is recommended inside that directory rather than at
+// the first candidate of [File]. An existing file is returned wherever it is found, as with [File].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - dirExist: Boolean indicating whether the directory exists on the filesystem
+//   - file: The configuration file path
+//   - fileStatus: A FileStatus value indicating whether the file exists, only its base directory exists, or neither exists
+func Resolve(app, name string) (dir string, dirExist bool, file string, fileStatus FileStatus) {
+	l := configLayout().cacheHome()
+	dir, status := searchDir(l.dirs(app))
+	dirExist = status == FileExists
+	cfg := newFileConfig(app, name)
+	file, fileStatus, _ = cfg.search(context.Background(), l, checkFile)
+	if fileStatus != FileExists && dirExist {
+		file = filepath.Join(dir, cfg.File)
+		fileStatus = checkFile(file)
+	}
+	return dir, dirExist, file, fileStatus
+}
@@ -0,0 +1,79 @@
+package dotconfig
+
+import (
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origDirExists := dirExists
+	origCheckFile := checkFile
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		dirExists = origDirExists
+		checkFile = origCheckFile
+		userHomeDir = origUserHomeDir
+	}()
+
+	// Mock functions
+	called := 0
+	xdgConfigHome = func() string { return "" }
+	userHomeDir = func() (string, error) {
+		called++
+		return "/mock/home", nil
+	}
+
+	testCases := []struct {
+		Name       string
+		DirExists  string
+		FileExists string
+		Dir        string
+		DirExist   bool
+		File       string
+		FileStatus FileStatus
+	}{
+		{
+			"nothing exists", "", "",
+			"/mock/home/.config/myapp", false, "/mock/home/.config/myapp/config.yaml", NotExists,
+		},
+		{
+			"file inside the existing directory", "/mock/home/.myapp", "",
+			"/mock/home/.myapp", true, "/mock/home/.myapp/config.yaml", BaseExists,
+		},
+		{
+			"existing file wins", "/mock/home/.myapp", "/mock/home/.myapp.yaml",
+			"/mock/home/.myapp", true, "/mock/home/.myapp.yaml", FileExists,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dirExists = func(dir string) bool { return dir == tc.DirExists }
+			checkFile = func(path string) FileStatus {
+				switch {
+				case path == tc.FileExists:
+					return FileExists
+				case tc.DirExists != "" && path == tc.DirExists+"/config.yaml":
+					return BaseExists
+				}
+				return NotExists
+			}
+			called = 0
+
+			dir, dirExist, file, fileStatus := Resolve("myapp", "config.yaml")
+
+			if dir != tc.Dir || dirExist != tc.DirExist {
+				t.Errorf("Expected dir '%s', %v, got '%s', %v", tc.Dir, tc.DirExist, dir, dirExist)
+			}
+			if file != tc.File || fileStatus != tc.FileStatus {
+				t.Errorf("Expected file '%s', %v, got '%s', %v", tc.File, tc.FileStatus, file, fileStatus)
+			}
+			if called != 1 {
+				t.Errorf("Expected userHomeDir to be called 1 time, got %d", called)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package dotconfig
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// resolver holds the functions installed by [SetHomeDirFunc] and [SetXDGConfigHomeFunc].
+// A nil function, like a nil resolver, stands for the default.
+//
+// A resolver is never modified once published; the setters replace it as a whole,
+// so that a search running concurrently, such as in [Watch], always sees a consistent value.
+type resolver struct {
+	home func() (string, error)
+	xdg  func() string
+}
+
+var currentResolver atomic.Pointer[resolver]
+
+func (r *resolver) homeDir() (string, error) {
+	if r == nil || r.home == nil {
+		return defaultHomeDir()
+	}
+	return r.home()
+}
+
+var osUserHomeDir = os.UserHomeDir
+
+// defaultHomeDir returns the home directory from [os.UserHomeDir], or else from HOME or USERPROFILE,
+// so that environments mixing conventions, such as Git Bash on Windows setting only HOME,
+// resolve the same home directory. The error of [os.UserHomeDir] is returned if none is set.
+func defaultHomeDir() (string, error) {
+	home, err := osUserHomeDir()
+	if err == nil { // if NO error
+		return home, nil
+	}
+	for _, key := range []string{"HOME", "USERPROFILE"} {
+		if home := os.Getenv(key); home != "" {
+			return home, nil
+		}
+	}
+	return "", err
+}
+
+func (r *resolver) xdgConfigHome() string {
+	if r == nil || r.xdg == nil {
+		return defaultXDGConfigHome()
+	}
+	return r.xdg()
+}
+
+// updateResolver publishes a copy of the current resolver modified by update.
+func updateResolver(update func(r *resolver)) {
+	for {
+		old := currentResolver.Load()
+		var r resolver
+		if old != nil {
+			r = *old
+		}
+		update(&r)
+		if currentResolver.CompareAndSwap(old, &r) {
+			return
+		}
+	}
+}
+
+// SetHomeDirFunc replaces the function used to determine the user's home directory.
+// Passing nil restores the default, [os.UserHomeDir] falling back to the HOME and USERPROFILE
+// environment variables.
+//
+// It affects every subsequent search and is intended for embedders and tests.
+// It is safe to call concurrently with a search, which uses either the old or the new function.
+func SetHomeDirFunc(fn func() (string, error)) {
+	updateResolver(func(r *resolver) {
+		r.home = fn
+	})
+}
+
+// SetXDGConfigHomeFunc replaces the function used to determine XDG_CONFIG_HOME.
+// Passing nil restores the default, which reads the XDG_CONFIG_HOME environment variable.
+//
+// It affects every subsequent search and is intended for embedders and tests.
+// It is safe to call concurrently with a search, which uses either the old or the new function.
+func SetXDGConfigHomeFunc(fn func() string) {
+	updateResolver(func(r *resolver) {
+		r.xdg = fn
+	})
+}
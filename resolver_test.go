@@ -0,0 +1,125 @@
+package dotconfig
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestSetHomeDirFunc(t *testing.T) {
+	// Save original functions to restore later
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		userHomeDir = origUserHomeDir
+	}()
+
+	SetHomeDirFunc(func() (string, error) { return "/mock/home", nil })
+	if home, _ := userHomeDir(); home != "/mock/home" {
+		t.Errorf("Expected %v, got %v", "/mock/home", home)
+	}
+
+	SetHomeDirFunc(nil)
+	t.Setenv("HOME", "/mock/env/home")
+	t.Setenv("USERPROFILE", "/mock/env/home")
+	t.Setenv("home", "/mock/env/home")
+	if home, _ := userHomeDir(); home != "/mock/env/home" {
+		t.Errorf("Expected %v, got %v", "/mock/env/home", home)
+	}
+}
+
+func TestDefaultHomeDir(t *testing.T) {
+	// Save original functions to restore later
+	origOSUserHomeDir := osUserHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		osUserHomeDir = origOSUserHomeDir
+	}()
+
+	// Mock functions
+	osUserHomeDir = func() (string, error) {
+		return "", errors.New("mock error")
+	}
+
+	testCases := []struct {
+		Home        string
+		UserProfile string
+		Expected    string
+	}{
+		{"/mock/home", "/mock/profile", "/mock/home"},
+		{"", "/mock/profile", "/mock/profile"},
+		{"", "", ""},
+	}
+	for _, tc := range testCases {
+		t.Setenv("HOME", tc.Home)
+		t.Setenv("USERPROFILE", tc.UserProfile)
+
+		home, err := defaultHomeDir()
+
+		if home != tc.Expected {
+			t.Errorf("Expected %v, got %v", tc.Expected, home)
+		}
+		if (err != nil) != (tc.Expected == "") {
+			t.Errorf("Expected an error only when no home is set, got %v", err)
+		}
+	}
+
+	t.Setenv("HOME", "/mock/home")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if dir, _ := Dir("myapp"); dir != filepath.Join("/mock/home", ".config", "myapp") {
+		t.Errorf("Expected dir to be under HOME, got '%s'", dir)
+	}
+
+	osUserHomeDir = func() (string, error) {
+		return "/mock/os/home", nil
+	}
+	t.Setenv("HOME", "/mock/home")
+	if home, _ := defaultHomeDir(); home != "/mock/os/home" {
+		t.Errorf("Expected %v, got %v", "/mock/os/home", home)
+	}
+}
+
+func TestSetXDGConfigHomeFunc(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+	}()
+
+	SetXDGConfigHomeFunc(func() string { return "/mock/xdg" })
+	if got := xdgConfigHome(); got != "/mock/xdg" {
+		t.Errorf("Expected %v, got %v", "/mock/xdg", got)
+	}
+
+	SetXDGConfigHomeFunc(nil)
+	t.Setenv("XDG_CONFIG_HOME", "/mock/env/xdg")
+	if got := xdgConfigHome(); got != "/mock/env/xdg" {
+		t.Errorf("Expected %v, got %v", "/mock/env/xdg", got)
+	}
+}
+
+func TestSetHomeDirFuncConcurrent(t *testing.T) {
+	defer SetHomeDirFunc(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			SetHomeDirFunc(func() (string, error) { return "/mock/home", nil })
+			SetHomeDirFunc(nil)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			Dir("myapp")
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,29 @@
+//go:build go1.24
+
+package dotconfig
+
+import (
+	"io/fs"
+	"os"
+)
+
+// DirRoot opens the configuration directory of the specified application, located using [Dir],
+// as an [os.Root], so that files named by untrusted strings cannot escape the directory
+// through ".." or symbolic links.
+//
+// If the directory does not exist, it returns an [*fs.PathError] wrapping [fs.ErrNotExist]
+// with the path that [Dir] returned. The caller must close the returned root.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//
+// Returns:
+//   - root: The opened configuration directory
+//   - err: An error if the directory does not exist or could not be opened
+func DirRoot(app string) (root *os.Root, err error) {
+	dir, exist := Dir(app)
+	if !exist {
+		return nil, &fs.PathError{Op: "open", Path: dir, Err: fs.ErrNotExist}
+	}
+	return os.OpenRoot(dir)
+}
@@ -0,0 +1,71 @@
+//go:build go1.24
+
+package dotconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirRoot(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	tmp := t.TempDir()
+	xdg := filepath.Join(tmp, "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("directory not exists", func(t *testing.T) {
+		root, err := DirRoot("myapp")
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected fs.ErrNotExist, got %v", err)
+		}
+		if root != nil {
+			t.Error("Expected root to be nil")
+		}
+	})
+
+	t.Run("directory exists", func(t *testing.T) {
+		dir := filepath.Join(xdg, "myapp")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("key: value\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(tmp, "secret"), []byte("secret"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		root, err := DirRoot("myapp")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		defer root.Close()
+
+		f, err := root.Open("config.yaml")
+		if err != nil {
+			t.Fatalf("Expected config.yaml to open, got %v", err)
+		}
+		f.Close()
+
+		if f, err := root.Open("../../secret"); err == nil {
+			f.Close()
+			t.Error("Expected opening a file outside the root to fail")
+		}
+	})
+}
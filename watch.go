@@ -0,0 +1,88 @@
+package dotconfig
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+const defaultPollInterval = time.Second
+
+// Watch reports changes of the configuration file for the specified application
+// until ctx is done, by polling at the interval set by [WithPollInterval].
+//
+// At each poll the file is located again using [FileWith] with opts, so that a file
+// created later, including one at a location of higher precedence, is picked up.
+// A change is any difference in the located path, in whether the file exists,
+// or in its identity, size, or modification time; this also catches files replaced by rename,
+// such as those written by [WriteFileAtomic]. On each change the located path is sent.
+//
+// The returned channel is closed when ctx is done.
+//
+// Parameters:
+//   - ctx: The context controlling how long to watch
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to watch
+//   - opts: The options to apply
+//
+// Returns:
+//   - A channel receiving the configuration file path on each change
+//   - err: The context error if ctx is already done
+func Watch(ctx context.Context, app, name string, opts ...Option) (<-chan string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	interval := newOptions(opts).pollInterval
+	ch := make(chan string)
+	prev := watchState(app, name, opts)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			state := watchState(app, name, opts)
+			if state.same(prev) {
+				continue
+			}
+			prev = state
+			select {
+			case ch <- state.path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// fileState is a snapshot of a watched configuration file.
+type fileState struct {
+	path string
+	info os.FileInfo // nil if the file does not exist
+}
+
+func watchState(app, name string, opts []Option) fileState {
+	path, status := FileWith(app, name, opts...)
+	state := fileState{path: path}
+	if status == FileExists {
+		if info, err := os.Stat(path); err == nil { // if NO error
+			state.info = info
+		}
+	}
+	return state
+}
+
+func (s fileState) same(t fileState) bool {
+	if s.path != t.path || (s.info == nil) != (t.info == nil) {
+		return false
+	}
+	if s.info == nil {
+		return true
+	}
+	return os.SameFile(s.info, t.info) && s.info.Size() == t.info.Size() && s.info.ModTime().Equal(t.info.ModTime())
+}
@@ -0,0 +1,93 @@
+package dotconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := t.TempDir()
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := Watch(ctx, "myapp", "config.yaml"); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("file appears and changes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		ch, err := Watch(ctx, "myapp", "config.yaml", WithPollInterval(5*time.Millisecond))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		receive := func() string {
+			t.Helper()
+			select {
+			case path := <-ch:
+				return path
+			case <-time.After(5 * time.Second):
+				t.Fatal("Expected a change to be reported")
+				return ""
+			}
+		}
+
+		if _, err := WriteFileAtomic("myapp", "config.yaml", []byte("a"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if path := receive(); path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+
+		if _, err := WriteFileAtomic("myapp", "config.yaml", []byte("bb"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if path := receive(); path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+
+		cancel()
+		for range ch {
+		}
+	})
+}
+
+func TestWatchNonPositiveInterval(t *testing.T) {
+	for _, d := range []time.Duration{0, -time.Second} {
+		if got := newOptions([]Option{WithPollInterval(d)}).pollInterval; got != defaultPollInterval {
+			t.Errorf("Expected interval %v for %v, got %v", defaultPollInterval, d, got)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, err := Watch(ctx, "myapp", "config.yaml", WithPollInterval(d))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		cancel()
+		for range ch {
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package dotconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// DefaultDirPerm is the permission with which configuration directories are created,
+	// unless [WithDirPerm] says otherwise. It keeps them private to the user.
+	DefaultDirPerm os.FileMode = 0700
+
+	// DefaultFilePerm is the permission with which configuration files are created,
+	// unless [WithFilePerm] says otherwise. It keeps them private to the user.
+	DefaultFilePerm os.FileMode = 0600
+)
+
+// EnsureDir locates the configuration directory for the specified application using [DirWith]
+// and creates it if it does not exist yet.
+//
+// The directory is created with the permission set by [WithDirPerm], which defaults to [DefaultDirPerm].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - opts: The options to apply
+//
+// Returns:
+//   - dir: The configuration directory path
+//   - err: An error if the directory could not be created
+func EnsureDir(app string, opts ...Option) (dir string, err error) {
+	o := newOptions(opts)
+	dir, exist := DirWith(app, opts...)
+	if !exist {
+		if err := os.MkdirAll(dir, o.dirPerm); err != nil {
+			return dir, err
+		}
+	}
+	return dir, nil
+}
+
+// WriteFile locates the configuration file for the specified application using [FileWith]
+// and writes data to it, creating its parent directories when needed.
+//
+// Directories are created with the permission set by [WithDirPerm], which defaults to [DefaultDirPerm],
+// and the file is created with the permission set by [WithFilePerm], which defaults to [DefaultFilePerm].
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to write
+//   - data: The contents to write
+//   - opts: The options to apply
+//
+// Returns:
+//   - path: The configuration file path
+//   - err: An error if the file could not be written
+func WriteFile(app, name string, data []byte, opts ...Option) (path string, err error) {
+	o := newOptions(opts)
+	path, status := FileWith(app, name, opts...)
+	if status == NotExists {
+		if err := os.MkdirAll(filepath.Dir(path), o.dirPerm); err != nil {
+			return path, err
+		}
+	}
+	return path, os.WriteFile(path, data, o.filePerm)
+}
+
+// WriteFileAtomic is like [WriteFile] but replaces the file atomically, so that a crash
+// in the middle of the write never leaves a truncated configuration file behind.
+//
+// The data is written to a temporary file in the same directory, synced to disk, and then
+// renamed into place. The temporary file is removed if any step fails.
+// Parent directories are created with the permission [DefaultDirPerm] when needed.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to write
+//   - data: The contents to write
+//   - perm: The permission of the written file
+//
+// Returns:
+//   - path: The configuration file path
+//   - err: An error if the file could not be written
+func WriteFileAtomic(app, name string, data []byte, perm os.FileMode) (path string, err error) {
+	path, status := File(app, name)
+	dir := filepath.Dir(path)
+	if status == NotExists {
+		if err := os.MkdirAll(dir, DefaultDirPerm); err != nil {
+			return path, err
+		}
+	}
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return path, err
+	}
+	tmp := f.Name()
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(tmp)
+		}
+	}()
+	if _, err = f.Write(data); err != nil {
+		return path, err
+	}
+	if err = f.Chmod(perm); err != nil {
+		return path, err
+	}
+	if err = f.Sync(); err != nil {
+		return path, err
+	}
+	if err = f.Close(); err != nil {
+		return path, err
+	}
+	return path, os.Rename(tmp, path)
+}
+
+// FileOrCreate locates the configuration file for the specified application using [File]
+// and returns it if it exists. Otherwise it creates the parent directory if needed and
+// writes defaultContent to the recommended location.
+//
+// If the file already exists, defaultContent is ignored. An existing file is never
+// overwritten, even if it appears between the search and the creation.
+//
+// Parameters:
+//   - app: The application name to search configurations for
+//   - name: The name of the configuration file to find or create
+//   - defaultContent: The contents of a newly created file
+//
+// Returns:
+//   - path: The configuration file path
+//   - created: Boolean indicating whether the file was created by this call
+//   - err: An error if the file could not be created
+func FileOrCreate(app, name string, defaultContent []byte) (path string, created bool, err error) {
+	path, status := File(app, name)
+	if status == FileExists {
+		return path, false, nil
+	}
+	if status == NotExists {
+		if err := os.MkdirAll(filepath.Dir(path), DefaultDirPerm); err != nil {
+			return path, false, err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, DefaultFilePerm)
+	if err != nil {
+		return path, false, err
+	}
+	if _, err := f.Write(defaultContent); err != nil {
+		f.Close()
+		return path, false, err
+	}
+	if err := f.Close(); err != nil {
+		return path, false, err
+	}
+	return path, true, nil
+}
+
+// IsWritable reports whether the current process can create files in the directory at path.
+// It probes by creating and removing a temporary file rather than inspecting permission bits,
+// so that read-only mounts and access control lists are taken into account.
+//
+// If path does not exist, its nearest existing ancestor is probed instead,
+// since that is where the missing directories would be created.
+// If path, or that ancestor, is not a directory, it reports false.
+//
+// Parameters:
+//   - path: The directory path to check, typically as returned by [Dir]
+//
+// Returns:
+//   - Boolean indicating whether the directory is writable
+func IsWritable(path string) bool {
+	dir := path
+	for {
+		info, err := os.Stat(dir)
+		if err == nil { // if NO error
+			if !info.IsDir() {
+				return false
+			}
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+	f, err := os.CreateTemp(dir, ".dotconfig-probe-*")
+	if err != nil {
+		return false
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return true
+}
@@ -0,0 +1,316 @@
+package dotconfig
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEnsureDir(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := filepath.Join(t.TempDir(), "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("default permission", func(t *testing.T) {
+		dir, err := EnsureDir("myapp")
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := filepath.Join(xdg, "myapp")
+		if dir != expected {
+			t.Errorf("Expected dir to be '%s', got '%s'", expected, dir)
+		}
+		checkPerm(t, dir, 0700)
+	})
+
+	t.Run("existing directory", func(t *testing.T) {
+		dir, err := EnsureDir("myapp", WithDirPerm(0755))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkPerm(t, dir, 0700)
+	})
+
+	t.Run("custom permission", func(t *testing.T) {
+		dir, err := EnsureDir("otherapp", WithDirPerm(0755))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkPerm(t, dir, 0755)
+	})
+}
+
+func TestWriteFile(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := filepath.Join(t.TempDir(), "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("default permission", func(t *testing.T) {
+		path, err := WriteFile("myapp", "config.yaml", []byte("key: value\n"))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "key: value\n" {
+			t.Errorf("Expected data to be 'key: value\\n', got '%s'", data)
+		}
+		checkPerm(t, filepath.Dir(path), 0700)
+		checkPerm(t, path, 0600)
+	})
+
+	t.Run("custom permission", func(t *testing.T) {
+		path, err := WriteFile("otherapp", "config.yaml", nil, WithDirPerm(0755), WithFilePerm(0644))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkPerm(t, filepath.Dir(path), 0755)
+		checkPerm(t, path, 0644)
+	})
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := filepath.Join(t.TempDir(), "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+
+	t.Run("new file", func(t *testing.T) {
+		path, err := WriteFileAtomic("myapp", "config.yaml", []byte("key: value\n"), 0644)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := filepath.Join(xdg, "myapp", "config.yaml")
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "key: value\n" {
+			t.Errorf("Expected data to be 'key: value\\n', got '%s'", data)
+		}
+		checkPerm(t, filepath.Dir(path), 0700)
+		checkPerm(t, path, 0644)
+	})
+
+	t.Run("replace existing file", func(t *testing.T) {
+		path, err := WriteFileAtomic("myapp", "config.yaml", []byte("key: other\n"), 0600)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "key: other\n" {
+			t.Errorf("Expected data to be 'key: other\\n', got '%s'", data)
+		}
+		entries, err := os.ReadDir(filepath.Dir(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("Expected only the config file to remain, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("rename fails", func(t *testing.T) {
+		// A directory in place of the file makes the rename fail.
+		if err := os.MkdirAll(filepath.Join(xdg, "otherapp", "config.yaml", "sub"), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := WriteFileAtomic("otherapp", "config.yaml", []byte("key: value\n"), 0600)
+
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		entries, err := os.ReadDir(filepath.Join(xdg, "otherapp"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("Expected temporary file to be removed, got %d entries", len(entries))
+		}
+	})
+}
+
+func checkPerm(t *testing.T, path string, expected os.FileMode) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The umask may clear bits, so only check that no extra bits are set.
+	if got := info.Mode().Perm(); got&^expected != 0 {
+		t.Errorf("Expected permission of '%s' to be at most %v, got %v", path, expected, got)
+	}
+}
+
+func TestFileOrCreate(t *testing.T) {
+	// Save original functions to restore later
+	origXdgConfigHome := xdgConfigHome
+	origUserHomeDir := userHomeDir
+
+	// Restore original functions after test
+	defer func() {
+		xdgConfigHome = origXdgConfigHome
+		userHomeDir = origUserHomeDir
+	}()
+
+	xdg := filepath.Join(t.TempDir(), "xdg")
+	xdgConfigHome = func() string { return xdg }
+	userHomeDir = func() (string, error) {
+		return "", os.ErrNotExist
+	}
+	expected := filepath.Join(xdg, "myapp", "config.yaml")
+
+	t.Run("create with default content", func(t *testing.T) {
+		path, created, err := FileOrCreate("myapp", "config.yaml", []byte("default: true\n"))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if !created {
+			t.Error("Expected created to be true")
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "default: true\n" {
+			t.Errorf("Expected data to be 'default: true\\n', got '%s'", data)
+		}
+		checkPerm(t, path, 0600)
+	})
+
+	t.Run("existing file is kept", func(t *testing.T) {
+		path, created, err := FileOrCreate("myapp", "config.yaml", []byte("other: true\n"))
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if path != expected {
+			t.Errorf("Expected path to be '%s', got '%s'", expected, path)
+		}
+		if created {
+			t.Error("Expected created to be false")
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "default: true\n" {
+			t.Errorf("Expected data to be 'default: true\\n', got '%s'", data)
+		}
+	})
+}
+
+func TestIsWritable(t *testing.T) {
+	base := t.TempDir()
+
+	t.Run("existing directory", func(t *testing.T) {
+		if !IsWritable(base) {
+			t.Error("Expected temporary directory to be writable")
+		}
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("Expected probe file to be removed, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		if !IsWritable(filepath.Join(base, "missing", "myapp")) {
+			t.Error("Expected missing directory under a writable ancestor to be writable")
+		}
+	})
+
+	t.Run("regular file", func(t *testing.T) {
+		file := filepath.Join(base, "file")
+		if err := os.WriteFile(file, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if IsWritable(file) {
+			t.Error("Expected regular file not to be writable as a directory")
+		}
+		if IsWritable(filepath.Join(file, "myapp")) {
+			t.Error("Expected directory under a regular file not to be writable")
+		}
+	})
+
+	t.Run("read-only directory", func(t *testing.T) {
+		if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+			t.Skip("permission bits are not enforced")
+		}
+		dir := filepath.Join(base, "readonly")
+		if err := os.Mkdir(dir, 0500); err != nil {
+			t.Fatal(err)
+		}
+		if IsWritable(dir) {
+			t.Error("Expected read-only directory not to be writable")
+		}
+		if IsWritable(filepath.Join(dir, "myapp")) {
+			t.Error("Expected missing directory under a read-only ancestor not to be writable")
+		}
+	})
+}